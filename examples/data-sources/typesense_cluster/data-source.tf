@@ -9,3 +9,8 @@ terraform {
 data "typesense_cluster" "example" {
   id = "<cluster-id>"
 }
+
+# Look up a cluster created elsewhere by its name.
+data "typesense_cluster" "shared" {
+  name = "shared-search"
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    typesense = {
+      source = "bananalab/terraform/typesense"
+    }
+  }
+}
+
+data "typesense_cluster_options" "example" {}
+
+variable "memory" {
+  type    = string
+  default = "2_gb"
+}
+
+# vCPU options that can be combined with the chosen memory.
+output "vcpu_options" {
+  value = one([for tier in data.typesense_cluster_options.example.memory_options : tier.vcpu if tier.value == var.memory])
+}
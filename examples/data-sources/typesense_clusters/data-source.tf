@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    typesense = {
+      source = "bananalab/terraform/typesense"
+    }
+  }
+}
+
+# Every cluster of the account.
+data "typesense_clusters" "all" {}
+
+# Production clusters in service in Oregon.
+data "typesense_clusters" "production" {
+  name_regex = "^prod-"
+  status     = "in_service"
+  region     = "oregon"
+}
+
+output "outdated_clusters" {
+  value = [for cluster in data.typesense_clusters.all.clusters : cluster.name if cluster.typesense_server_version != "0.25.0"]
+}
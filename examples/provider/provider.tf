@@ -1,3 +1,6 @@
 provider "typesense" {
-  key = "foobarbaz"  # Or use TYPESENSE_MANAGEMENT_KEY envvar
+  key = "foobarbaz" # Or use TYPESENSE_MANAGEMENT_KEY envvar
+
+  # Fail plans that would bring the estimated cost of the account's clusters above 500 USD per month.
+  max_monthly_cost = 500
 }
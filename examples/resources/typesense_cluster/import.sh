@@ -1,2 +1,5 @@
 # Cluster can be imported by specifying the Typesense Cluster ID.
 terraform import typesense_cluster.example [cluster_id]
+
+# Cluster can also be imported by its name, which must match exactly one cluster that is not terminated.
+terraform import typesense_cluster.example name:[cluster_name]
@@ -8,7 +8,29 @@ terraform {
 }
 
 resource "typesense_cluster" "example" {
-  memory = "0.5_gb"
-  vcpu   = "2_vcpus_1_hr_burst_per_day"
-  region = "oregon"
+  memory  = "0.5_gb"
+  vcpu    = "2_vcpus_1_hr_burst_per_day"
+  regions = ["oregon"]
+}
+
+# Cluster spread over several regions with Search Delivery Network.
+resource "typesense_cluster" "global" {
+  memory                  = "2_gb"
+  vcpu                    = "2_vcpus"
+  high_availability       = "yes"
+  search_delivery_network = "automatic"
+  regions                 = ["oregon", "frankfurt", "singapore"]
+}
+
+# Highly available cluster of 5 nodes behind a load balancer.
+resource "typesense_cluster" "ha" {
+  memory            = "4_gb"
+  vcpu              = "2_vcpus"
+  high_availability = "yes"
+  node_count        = 5
+  load_balancing    = "yes"
+  regions           = ["oregon"]
+
+  # Refuse to destroy or replace the production cluster.
+  deletion_protection = true
 }
@@ -0,0 +1,2 @@
+# Configuration changes can be imported by specifying the cluster ID and the configuration change ID.
+terraform import typesense_cluster_configuration_change.example [cluster_id]/[configuration_change_id]
@@ -0,0 +1,7 @@
+# Resize a cluster during the next maintenance window.
+resource "typesense_cluster_configuration_change" "example" {
+  cluster_id        = "kvzb3qlwp27v19r4b"
+  new_memory        = "4_gb"
+  new_vcpu          = "2_vcpus"
+  perform_change_at = "2023-06-04T02:00:00Z"
+}
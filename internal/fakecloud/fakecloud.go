@@ -0,0 +1,649 @@
+// Package fakecloud implements an in-process stand-in for the Typesense Cloud
+// Management API so that the provider can be tested without network access
+// and without creating billable clusters.
+//
+// Clusters and configuration changes go through the same statuses as on
+// Typesense Cloud. Transient statuses last for TransitionPolls reads, which
+// keeps tests deterministic without sleeping.
+package fakecloud
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyHeader carries the Cloud Management API key.
+const APIKeyHeader = "X-TYPESENSE-CLOUD-MANAGEMENT-API-KEY"
+
+// Cluster statuses.
+const (
+	StatusInitializing = "initializing"
+	StatusInService    = "in_service"
+	StatusFailed       = "failed"
+	StatusTerminating  = "terminating"
+	StatusTerminated   = "terminated"
+)
+
+// Configuration change statuses.
+const (
+	ChangeScheduled  = "scheduled"
+	ChangeInProgress = "in_progress"
+	ChangeCompleted  = "completed"
+	ChangeCancelled  = "cancelled"
+)
+
+// Cluster is a cluster as returned by the API.
+type Cluster struct {
+	ID                     string    `json:"id"`
+	Name                   string    `json:"name"`
+	Memory                 string    `json:"memory"`
+	VCPU                   string    `json:"vcpu"`
+	HighPerformanceDisk    string    `json:"high_performance_disk"`
+	TypesenseServerVersion string    `json:"typesense_server_version"`
+	HighAvailability       string    `json:"high_availability"`
+	SearchDeliveryNetwork  string    `json:"search_delivery_network"`
+	LoadBalancing          string    `json:"load_balancing"`
+	NodeCount              int       `json:"node_count"`
+	Regions                []string  `json:"regions"`
+	AutoUpgradeCapacity    bool      `json:"auto_upgrade_capacity"`
+	Status                 string    `json:"status"`
+	Hostnames              Hostnames `json:"hostnames"`
+}
+
+// Hostnames are the endpoints of a cluster.
+type Hostnames struct {
+	LoadBalanced string   `json:"load_balanced"`
+	NearestNode  string   `json:"nearest_node,omitempty"`
+	Nodes        []string `json:"nodes"`
+}
+
+// ConfigurationChange is a resize or upgrade of a cluster.
+type ConfigurationChange struct {
+	ID                        string `json:"id"`
+	ClusterID                 string `json:"cluster_id"`
+	NewMemory                 string `json:"new_memory,omitempty"`
+	NewVCPU                   string `json:"new_vcpu,omitempty"`
+	NewHighPerformanceDisk    string `json:"new_high_performance_disk,omitempty"`
+	NewTypesenseServerVersion string `json:"new_typesense_server_version,omitempty"`
+	PerformChangeAt           int64  `json:"perform_change_at,omitempty"`
+	Status                    string `json:"status"`
+	CompletedAt               int64  `json:"completed_at,omitempty"`
+}
+
+// Fault is an error response returned instead of handling a request.
+type Fault struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Method and PathPrefix restrict the requests the fault applies to.
+	// Empty values match any request.
+	Method     string
+	PathPrefix string
+	// RetryAfter is sent as the Retry-After header when not empty.
+	RetryAfter string
+}
+
+type injectedFault struct {
+	Fault
+	remaining int
+}
+
+// Server is a fake Cloud Management API served over HTTP.
+type Server struct {
+	*httptest.Server
+
+	// Key is the Cloud Management API key requests must carry. Any key is
+	// accepted when empty.
+	Key string
+	// TransitionPolls is the number of reads a cluster or configuration
+	// change stays in a transient status, such as initializing.
+	TransitionPolls int
+	// Latency is added to every response.
+	Latency time.Duration
+	// DefaultServerVersion is the Typesense version of new clusters.
+	DefaultServerVersion string
+	// ProvisionedStatus is the status new clusters reach once initialized,
+	// StatusInService unless a provisioning failure is simulated.
+	ProvisionedStatus string
+	// MaxPerPage caps the page size of cluster listings.
+	MaxPerPage int
+	// OmitTotal leaves the total number of clusters out of cluster listings.
+	OmitTotal bool
+
+	mu       sync.Mutex
+	clusters map[string]*clusterState
+	changes  []*changeState
+	faults   []*injectedFault
+	requests int
+}
+
+type clusterState struct {
+	Cluster
+	polls int
+}
+
+type changeState struct {
+	ConfigurationChange
+	polls int
+}
+
+// NewServer starts a fake API accepting key. The caller must Close it.
+func NewServer(key string) *Server {
+	s := &Server{
+		Key:                  key,
+		TransitionPolls:      1,
+		DefaultServerVersion: "0.24.1",
+		ProvisionedStatus:    StatusInService,
+		MaxPerPage:           250,
+		clusters:             map[string]*clusterState{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddCluster stores cluster as is, generating an ID when it has none, and
+// returns the stored cluster.
+func (s *Server) AddCluster(cluster Cluster) Cluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cluster.ID == "" {
+		cluster.ID = newID()
+	}
+	if cluster.Status == "" {
+		cluster.Status = StatusInService
+	}
+	s.clusters[cluster.ID] = &clusterState{Cluster: cluster}
+	return cluster
+}
+
+// Cluster returns the cluster with the given ID.
+func (s *Server) Cluster(id string) (Cluster, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cluster, ok := s.clusters[id]
+	if !ok {
+		return Cluster{}, false
+	}
+	return cluster.Cluster, true
+}
+
+// SetClusterStatus overrides the status of a cluster, for example to
+// simulate a termination from the web console.
+func (s *Server) SetClusterStatus(id, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cluster, ok := s.clusters[id]; ok {
+		cluster.Status = status
+		cluster.polls = 0
+	}
+}
+
+// SetClusterVersion overrides the Typesense server version of a cluster, for
+// example to simulate an upgrade from the web console.
+func (s *Server) SetClusterVersion(id, version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cluster, ok := s.clusters[id]; ok {
+		cluster.TypesenseServerVersion = version
+	}
+}
+
+// ConfigurationChanges returns the configuration changes of a cluster.
+func (s *Server) ConfigurationChanges(clusterID string) []ConfigurationChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clusterChanges(clusterID)
+}
+
+// InjectFault makes the next times matching requests fail with fault.
+func (s *Server) InjectFault(fault Fault, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &injectedFault{Fault: fault, remaining: times})
+}
+
+// Requests returns the number of requests received so far.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Latency > 0 {
+		select {
+		case <-time.After(s.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if s.Key != "" && r.Header.Get(APIKeyHeader) != s.Key {
+		writeError(w, http.StatusUnauthorized, "Invalid API key")
+		return
+	}
+	if fault := s.takeFault(r); fault != nil {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeError(w, fault.Status, http.StatusText(fault.Status))
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) == 0 || segments[0] != "clusters" {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.listClusters(w, r)
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		s.createCluster(w, r)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		s.getCluster(w, segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPatch:
+		s.updateCluster(w, r, segments[1])
+	case len(segments) == 3 && segments[2] == "lifecycle" && r.Method == http.MethodPost:
+		s.lifecycle(w, r, segments[1])
+	case len(segments) == 3 && segments[2] == "api-keys" && r.Method == http.MethodPost:
+		s.createAPIKeys(w, segments[1])
+	case len(segments) == 3 && segments[2] == "configuration-changes" && r.Method == http.MethodPost:
+		s.createChange(w, r, segments[1])
+	case len(segments) == 3 && segments[2] == "configuration-changes" && r.Method == http.MethodGet:
+		s.listChanges(w, segments[1])
+	case len(segments) == 4 && segments[2] == "configuration-changes" && r.Method == http.MethodGet:
+		s.getChange(w, segments[1], segments[3])
+	case len(segments) == 4 && segments[2] == "configuration-changes" && r.Method == http.MethodDelete:
+		s.cancelChange(w, segments[1], segments[3])
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			continue
+		}
+		fault.remaining--
+		if fault.remaining <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return &fault.Fault
+	}
+	return nil
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name                   string   `json:"name"`
+		TypesenseServerVersion string   `json:"typesense_server_version"`
+		Memory                 string   `json:"memory"`
+		VCPU                   string   `json:"vcpu"`
+		HighPerformanceDisk    string   `json:"high_performance_disk"`
+		HighAvailability       string   `json:"high_availability"`
+		SearchDeliveryNetwork  string   `json:"search_delivery_network"`
+		LoadBalancing          string   `json:"load_balancing"`
+		NodeCount              int      `json:"node_count"`
+		Regions                []string `json:"regions"`
+		AutoUpgradeCapacity    bool     `json:"auto_upgrade_capacity"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.Memory == "" || params.VCPU == "" || len(params.Regions) == 0 {
+		writeError(w, http.StatusBadRequest, "memory, vcpu and regions are required")
+		return
+	}
+	cluster := &clusterState{Cluster: Cluster{
+		ID:                     newID(),
+		Name:                   params.Name,
+		Memory:                 params.Memory,
+		VCPU:                   params.VCPU,
+		HighPerformanceDisk:    valueOr(params.HighPerformanceDisk, "no"),
+		TypesenseServerVersion: valueOr(params.TypesenseServerVersion, s.DefaultServerVersion),
+		HighAvailability:       valueOr(params.HighAvailability, "no"),
+		SearchDeliveryNetwork:  valueOr(params.SearchDeliveryNetwork, "off"),
+		LoadBalancing:          "no",
+		Regions:                params.Regions,
+		AutoUpgradeCapacity:    params.AutoUpgradeCapacity,
+		Status:                 StatusInitializing,
+	}}
+	if cluster.Name == "" {
+		cluster.Name = cluster.ID
+	}
+	cluster.NodeCount = 1
+	if cluster.HighAvailability == "yes" {
+		cluster.NodeCount = 3
+		cluster.LoadBalancing = valueOr(params.LoadBalancing, "yes")
+	} else if params.LoadBalancing == "yes" || params.NodeCount > 1 {
+		writeError(w, http.StatusBadRequest, "load_balancing and multiple nodes require high_availability")
+		return
+	}
+	if params.NodeCount > 0 {
+		cluster.NodeCount = params.NodeCount
+	}
+	if cluster.LoadBalancing == "yes" {
+		cluster.Hostnames.LoadBalanced = cluster.ID + ".a1.typesense.net"
+	}
+	if cluster.SearchDeliveryNetwork != "off" {
+		cluster.Hostnames.NearestNode = cluster.ID + "-nearest.a1.typesense.net"
+	}
+	for i := 1; i <= cluster.NodeCount; i++ {
+		cluster.Hostnames.Nodes = append(cluster.Hostnames.Nodes, fmt.Sprintf("%s-%d.a1.typesense.net", cluster.ID, i))
+	}
+	s.clusters[cluster.ID] = cluster
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"success": true, "cluster": cluster.Cluster})
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request) {
+	page, err := queryInt(r, "page", 1)
+	if err != nil || page < 1 {
+		writeError(w, http.StatusBadRequest, "invalid page")
+		return
+	}
+	perPage, err := queryInt(r, "per_page", 10)
+	if err != nil || perPage < 1 {
+		writeError(w, http.StatusBadRequest, "invalid per_page")
+		return
+	}
+	if perPage > s.MaxPerPage {
+		perPage = s.MaxPerPage
+	}
+	ids := make([]string, 0, len(s.clusters))
+	for id := range s.clusters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	clusters := []Cluster{}
+	for i := (page - 1) * perPage; i < len(ids) && i < page*perPage; i++ {
+		cluster := s.clusters[ids[i]]
+		s.advanceCluster(cluster)
+		clusters = append(clusters, cluster.Cluster)
+	}
+	response := map[string]interface{}{
+		"page":     page,
+		"per_page": perPage,
+		"total":    len(ids),
+		"clusters": clusters,
+	}
+	if s.OmitTotal {
+		delete(response, "total")
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	s.advanceCluster(cluster)
+	writeJSON(w, http.StatusOK, cluster.Cluster)
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	var params map[string]json.RawMessage
+	if !decode(w, r, &params) {
+		return
+	}
+	if name, ok := params["name"]; ok {
+		_ = json.Unmarshal(name, &cluster.Name)
+	}
+	if autoUpgrade, ok := params["auto_upgrade_capacity"]; ok {
+		_ = json.Unmarshal(autoUpgrade, &cluster.AutoUpgradeCapacity)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": true, "cluster": cluster.Cluster})
+}
+
+func (s *Server) lifecycle(w http.ResponseWriter, r *http.Request, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	var params struct {
+		LifecycleAction string `json:"lifecycle_action"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.LifecycleAction != "terminate" {
+		writeError(w, http.StatusBadRequest, "Unsupported lifecycle action "+params.LifecycleAction)
+		return
+	}
+	if cluster.Status == StatusTerminating || cluster.Status == StatusTerminated {
+		writeError(w, http.StatusConflict, "Cluster is already terminated")
+		return
+	}
+	cluster.Status = StatusTerminating
+	cluster.polls = 0
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
+
+func (s *Server) createAPIKeys(w http.ResponseWriter, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	if cluster.Status != StatusInService {
+		writeError(w, http.StatusConflict, "Cluster is not in service")
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"api_keys": map[string]string{
+			"admin_key":       newID() + newID(),
+			"search_only_key": newID() + newID(),
+		},
+	})
+}
+
+func (s *Server) createChange(w http.ResponseWriter, r *http.Request, clusterID string) {
+	cluster, ok := s.clusters[clusterID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	var change ConfigurationChange
+	if !decode(w, r, &change) {
+		return
+	}
+	if cluster.Status != StatusInService {
+		writeError(w, http.StatusConflict, "Cluster is not in service")
+		return
+	}
+	for _, existing := range s.clusterChanges(clusterID) {
+		if existing.Status == ChangeInProgress || (existing.Status == ChangeScheduled && change.PerformChangeAt == 0) {
+			writeError(w, http.StatusConflict, "Another configuration change is pending")
+			return
+		}
+	}
+	change.ID = newID()
+	change.ClusterID = clusterID
+	change.Status = ChangeInProgress
+	if change.PerformChangeAt > time.Now().Unix() {
+		change.Status = ChangeScheduled
+	}
+	s.changes = append(s.changes, &changeState{ConfigurationChange: change})
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"success": true, "configuration_change": change})
+}
+
+func (s *Server) listChanges(w http.ResponseWriter, clusterID string) {
+	if _, ok := s.clusters[clusterID]; !ok {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	for _, change := range s.changes {
+		if change.ClusterID == clusterID {
+			s.advanceChange(change)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"configuration_changes": s.clusterChanges(clusterID)})
+}
+
+func (s *Server) getChange(w http.ResponseWriter, clusterID, id string) {
+	change := s.findChange(clusterID, id)
+	if change == nil {
+		writeError(w, http.StatusNotFound, "Configuration change not found")
+		return
+	}
+	s.advanceChange(change)
+	writeJSON(w, http.StatusOK, change.ConfigurationChange)
+}
+
+func (s *Server) cancelChange(w http.ResponseWriter, clusterID, id string) {
+	change := s.findChange(clusterID, id)
+	if change == nil {
+		writeError(w, http.StatusNotFound, "Configuration change not found")
+		return
+	}
+	if change.Status != ChangeScheduled {
+		writeError(w, http.StatusConflict, "Only scheduled configuration changes can be cancelled")
+		return
+	}
+	change.Status = ChangeCancelled
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
+
+// advanceCluster moves a cluster out of a transient status once it has been
+// read TransitionPolls times.
+func (s *Server) advanceCluster(cluster *clusterState) {
+	for _, change := range s.changes {
+		if change.ClusterID == cluster.ID {
+			s.advanceChange(change)
+		}
+	}
+	switch cluster.Status {
+	case StatusInitializing, StatusTerminating:
+		cluster.polls++
+		if cluster.polls < s.TransitionPolls {
+			return
+		}
+		cluster.polls = 0
+		if cluster.Status == StatusInitializing {
+			cluster.Status = s.ProvisionedStatus
+		} else {
+			cluster.Status = StatusTerminated
+		}
+	}
+}
+
+// advanceChange starts scheduled changes that are due and completes in
+// progress changes once they have been read TransitionPolls times.
+func (s *Server) advanceChange(change *changeState) {
+	if change.Status == ChangeScheduled && change.PerformChangeAt <= time.Now().Unix() {
+		change.Status = ChangeInProgress
+	}
+	if change.Status != ChangeInProgress {
+		return
+	}
+	change.polls++
+	if change.polls < s.TransitionPolls {
+		return
+	}
+	if cluster, ok := s.clusters[change.ClusterID]; ok {
+		cluster.Memory = valueOr(change.NewMemory, cluster.Memory)
+		cluster.VCPU = valueOr(change.NewVCPU, cluster.VCPU)
+		cluster.HighPerformanceDisk = valueOr(change.NewHighPerformanceDisk, cluster.HighPerformanceDisk)
+		cluster.TypesenseServerVersion = valueOr(change.NewTypesenseServerVersion, cluster.TypesenseServerVersion)
+	}
+	change.Status = ChangeCompleted
+	change.CompletedAt = time.Now().Unix()
+}
+
+func (s *Server) findChange(clusterID, id string) *changeState {
+	for _, change := range s.changes {
+		if change.ID == id && change.ClusterID == clusterID {
+			return change
+		}
+	}
+	return nil
+}
+
+func (s *Server) clusterChanges(clusterID string) []ConfigurationChange {
+	changes := []ConfigurationChange{}
+	for _, change := range s.changes {
+		if change.ClusterID == clusterID {
+			changes = append(changes, change.ConfigurationChange)
+		}
+	}
+	return changes
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if len(body) == 0 {
+		return true
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", newID())
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"success": false, "message": message})
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// queryInt returns the integer query parameter name of r, or fallback when absent.
+func queryInt(r *http.Request, name string, fallback int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// newID returns a random 17 character identifier like the ones Typesense Cloud assigns.
+func newID() string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 17)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
@@ -12,6 +12,11 @@ import (
 // Provider documentation generation.
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-name typesense
 
+var (
+	// version is set to the provider release version by goreleaser at build time.
+	version string = "dev"
+)
+
 func main() {
 	var debug bool
 
@@ -23,7 +28,7 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), typesense.New, opts)
+	err := providerserver.Serve(context.Background(), typesense.New(version), opts)
 
 	if err != nil {
 		log.Fatal(err.Error())
@@ -0,0 +1,160 @@
+package typesense
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/agext/levenshtein"
+)
+
+// catalogJSON lists the cluster configurations offered by Typesense Cloud.
+// It mirrors https://typesense.org/docs/cloud-management-api/v1/cluster-management.html
+// and must be updated when new options become available.
+//
+//go:embed catalog.json
+var catalogJSON []byte
+
+// clusterCatalog holds the memory tiers, vCPU options, regions and server
+// versions a cluster can be created with, and which of them can be combined.
+type clusterCatalog struct {
+	Memory                  []catalogMemoryTier `json:"memory"`
+	VCPU                    []catalogVCPUOption `json:"vcpu"`
+	Regions                 []catalogRegion     `json:"regions"`
+	TypesenseServerVersions []string            `json:"typesense_server_versions"`
+}
+
+// catalogMemoryTier is an amount of RAM and the vCPU options available with it.
+type catalogMemoryTier struct {
+	Value string   `json:"value"`
+	VCPU  []string `json:"vcpu"`
+}
+
+// catalogVCPUOption is a number of CPU cores and the features available with it.
+type catalogVCPUOption struct {
+	Value string `json:"value"`
+	// Burst options only run at full speed for a few hours per day.
+	Burst               bool `json:"burst"`
+	HighPerformanceDisk bool `json:"high_performance_disk"`
+	HighAvailability    bool `json:"high_availability"`
+}
+
+// catalogRegion is a region nodes can be placed in.
+type catalogRegion struct {
+	Value string `json:"value"`
+	Name  string `json:"name"`
+}
+
+// catalog is the embedded catalog, loaded once.
+var catalog = mustParseCatalog(catalogJSON)
+
+// parseCatalog decodes and validates a catalog.
+func parseCatalog(data []byte) (*clusterCatalog, error) {
+	var c clusterCatalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// validate checks that the catalog offers options and that every memory tier
+// refers to known vCPU options.
+func (c *clusterCatalog) validate() error {
+	if len(c.Memory) == 0 || len(c.VCPU) == 0 || len(c.Regions) == 0 {
+		return fmt.Errorf("catalog lacks memory, vcpu or region options")
+	}
+	for _, tier := range c.Memory {
+		for _, vcpu := range tier.VCPU {
+			if _, ok := c.vcpuOption(vcpu); !ok {
+				return fmt.Errorf("memory %q refers to unknown vcpu %q", tier.Value, vcpu)
+			}
+		}
+	}
+	return nil
+}
+
+func mustParseCatalog(data []byte) *clusterCatalog {
+	c, err := parseCatalog(data)
+	if err != nil {
+		panic("invalid embedded catalog: " + err.Error())
+	}
+	return c
+}
+
+// memoryTier returns the memory tier with the given value.
+func (c *clusterCatalog) memoryTier(value string) (catalogMemoryTier, bool) {
+	for _, tier := range c.Memory {
+		if tier.Value == value {
+			return tier, true
+		}
+	}
+	return catalogMemoryTier{}, false
+}
+
+// vcpuOption returns the vCPU option with the given value.
+func (c *clusterCatalog) vcpuOption(value string) (catalogVCPUOption, bool) {
+	for _, option := range c.VCPU {
+		if option.Value == value {
+			return option, true
+		}
+	}
+	return catalogVCPUOption{}, false
+}
+
+// memoryValues returns the values of all memory tiers.
+func (c *clusterCatalog) memoryValues() []string {
+	values := make([]string, len(c.Memory))
+	for i, tier := range c.Memory {
+		values[i] = tier.Value
+	}
+	return values
+}
+
+// vcpuValues returns the values of all vCPU options.
+func (c *clusterCatalog) vcpuValues() []string {
+	values := make([]string, len(c.VCPU))
+	for i, option := range c.VCPU {
+		values[i] = option.Value
+	}
+	return values
+}
+
+// regionValues returns the values of all regions.
+func (c *clusterCatalog) regionValues() []string {
+	values := make([]string, len(c.Regions))
+	for i, region := range c.Regions {
+		values[i] = region.Value
+	}
+	return values
+}
+
+// allowsVCPU reports whether the vCPU option is available with the memory tier.
+func (t catalogMemoryTier) allowsVCPU(vcpu string) bool {
+	for _, v := range t.VCPU {
+		if v == vcpu {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns the candidate closest to value, or "" when none is close
+// enough to be a likely typo.
+func suggest(value string, candidates []string) string {
+	lowered := strings.ToLower(value)
+	maxDistance := len(lowered) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if d := levenshtein.Distance(lowered, candidate, nil); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,41 @@
+package typesense
+
+import (
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	for _, tier := range catalog.Memory {
+		if len(tier.VCPU) == 0 {
+			t.Errorf("memory %q has no vcpu option", tier.Value)
+		}
+	}
+	if len(catalog.Regions) == 0 {
+		t.Error("expected regions in the catalog")
+	}
+
+	_, err := parseCatalog([]byte(`{"memory": [{"value": "1_gb", "vcpu": ["3_vcpus"]}], "vcpu": [{"value": "2_vcpus"}], "regions": [{"value": "oregon"}]}`))
+	if err == nil {
+		t.Error("expected an error for a memory tier referring to an unknown vcpu option")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "0.5gb", want: "0.5_gb"},
+		{value: "1GB", want: "1_gb"},
+		{value: "2_vcpu", want: "2_vcpus"},
+		{value: "2_vcpus_1hr_burst_per_day", want: "2_vcpus_1_hr_burst_per_day"},
+		{value: "oregn", want: "oregon"},
+		{value: "mars", want: ""},
+	}
+	candidates := append(append(catalog.memoryValues(), catalog.vcpuValues()...), catalog.regionValues()...)
+	for _, tt := range tests {
+		if got := suggest(tt.value, candidates); got != tt.want {
+			t.Errorf("suggest(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
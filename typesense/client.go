@@ -2,31 +2,77 @@ package typesense
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const clusterEndpoint = "https://cloud.typesense.org/api/v1/clusters"
+// defaultEndpoint is the base URL of the Typesense Cloud Management API.
+const defaultEndpoint = "https://cloud.typesense.org/api/v1"
+
+// clustersPerPage is the page size requested when listing clusters.
+const clustersPerPage = 100
+
+const (
+	defaultMaxRetries   = 4
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+
+	defaultRequestTimeout = 60 * time.Second
+
+	defaultMaxRequestsPerSecond  = 5
+	defaultMaxConcurrentRequests = 5
+)
+
+// Cluster lifecycle statuses reported by the Cloud Management API.
+const (
+	clusterStatusInService   = "in_service"
+	clusterStatusTerminating = "terminating"
+	clusterStatusTerminated  = "terminated"
+	clusterStatusFailed      = "failed"
+)
 
 type typesenseCluster struct {
-	ID                     string   `json:"id"`
-	Name                   string   `json:"name"`
-	Memory                 string   `json:"memory"`
-	VCPU                   string   `json:"vcpu"`
-	HighPerformanceDisk    string   `json:"high_performance_disk"`
-	TypesenseServerVersion string   `json:"typesense_server_version"`
-	HighAvailability       string   `json:"high_availability"`
-	SearchDeliveryNetwork  string   `json:"search_delivery_network"`
-	LoadBalancing          string   `json:"load_balancing"`
-	Regions                []string `json:"regions"`
-	AutoUpgradeCapacity    bool     `json:"auto_upgrade_capacity"`
-	Status                 string   `json:"status"`
-	Hostnames              struct {
-		LoadBalanced string   `json:"load_balanced"`
-		Nodes        []string `json:"nodes"`
-	} `json:"hostnames"`
+	ID                     string                    `json:"id"`
+	Name                   string                    `json:"name"`
+	Memory                 string                    `json:"memory"`
+	VCPU                   string                    `json:"vcpu"`
+	HighPerformanceDisk    string                    `json:"high_performance_disk"`
+	TypesenseServerVersion string                    `json:"typesense_server_version"`
+	HighAvailability       string                    `json:"high_availability"`
+	SearchDeliveryNetwork  string                    `json:"search_delivery_network"`
+	LoadBalancing          string                    `json:"load_balancing"`
+	Regions                []string                  `json:"regions"`
+	NodeCount              int64                     `json:"node_count,omitempty"`
+	AutoUpgradeCapacity    bool                      `json:"auto_upgrade_capacity"`
+	Status                 string                    `json:"status"`
+	Hostnames              typesenseClusterHostnames `json:"hostnames"`
+}
+
+// typesenseClusterHostnames are the hostnames a cluster is reachable at.
+type typesenseClusterHostnames struct {
+	LoadBalanced string   `json:"load_balanced"`
+	NearestNode  string   `json:"nearest_node"`
+	Nodes        []string `json:"nodes"`
+}
+
+type typesenseClusterListResponse struct {
+	Page     int                `json:"page"`
+	PerPage  int                `json:"per_page"`
+	Total    int                `json:"total"`
+	Clusters []typesenseCluster `json:"clusters"`
 }
 
 type typesenseClusterCreateResponse struct {
@@ -34,128 +80,490 @@ type typesenseClusterCreateResponse struct {
 	Cluster typesenseCluster `json:"cluster"`
 }
 
-func NewClient(key string) (*typesenseClient, error) {
-	return &typesenseClient{key: key}, nil
+// CloudClient is the set of Cloud Management API operations used by the
+// resources and data sources. typesenseClient implements it over HTTP, tests
+// point it at the fake API of internal/fakecloud.
+type CloudClient interface {
+	GetCluster(ctx context.Context, id string) (*typesenseCluster, error)
+	ListClusters(ctx context.Context) ([]typesenseCluster, error)
+	CreateCluster(ctx context.Context, model typesenseCluster) (*typesenseCluster, error)
+	UpdateCluster(ctx context.Context, model typesenseCluster) error
+	TerminateCluster(ctx context.Context, id string) error
+
+	CreateConfigurationChange(ctx context.Context, model typesenseConfigurationChange) (*typesenseConfigurationChange, error)
+	GetConfigurationChange(ctx context.Context, clusterID, id string) (*typesenseConfigurationChange, error)
+	ListConfigurationChanges(ctx context.Context, clusterID string) ([]typesenseConfigurationChange, error)
+	CancelConfigurationChange(ctx context.Context, clusterID, id string) error
+
+	CreateClusterApiKeys(ctx context.Context, model typesenseClusterApiKeys) (*typesenseClusterApiKeys, error)
 }
 
-type typesenseClient struct {
-	key string
+// Ensure typesenseClient satisfies the CloudClient interface.
+var _ CloudClient = &typesenseClient{}
+
+// clientConfig holds the settings used to build a typesenseClient.
+type clientConfig struct {
+	// Key is the Cloud Management API key.
+	Key string
+	// Endpoint is the base URL of the Cloud Management API, defaultEndpoint when empty.
+	Endpoint string
+	// ProviderVersion and TerraformVersion identify the caller in the User-Agent header.
+	ProviderVersion  string
+	TerraformVersion string
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// MaxRequestsPerSecond and MaxConcurrentRequests throttle the requests
+	// shared by all resources using the client. Zero disables the limit.
+	MaxRequestsPerSecond  float64
+	MaxConcurrentRequests int
+	// Transport holds the proxy, TLS and timeout settings of the HTTP client.
+	Transport transportConfig
 }
 
-func (c *typesenseClient) GetCluster(id string) (*typesenseCluster, error) {
-	req, err := http.NewRequest("GET", clusterEndpoint+"/"+id, nil)
+func NewClient(config clientConfig) (*typesenseClient, error) {
+	if config.Endpoint == "" {
+		config.Endpoint = defaultEndpoint
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", config.Endpoint, err)
+	}
+	if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: expected an absolute http or https URL", config.Endpoint)
+	}
+	if config.MaxRetries < 0 {
+		return nil, fmt.Errorf("max retries must not be negative, got %d", config.MaxRetries)
+	}
+	if config.RetryWaitMin <= 0 {
+		config.RetryWaitMin = defaultRetryWaitMin
+	}
+	if config.RetryWaitMax <= 0 {
+		config.RetryWaitMax = defaultRetryWaitMax
+	}
+	if config.MaxRequestsPerSecond < 0 {
+		return nil, fmt.Errorf("max requests per second must not be negative, got %g", config.MaxRequestsPerSecond)
+	}
+	if config.MaxConcurrentRequests < 0 {
+		return nil, fmt.Errorf("max concurrent requests must not be negative, got %d", config.MaxConcurrentRequests)
+	}
+	if config.RetryWaitMax < config.RetryWaitMin {
+		return nil, fmt.Errorf("retry wait max (%s) must not be lower than retry wait min (%s)", config.RetryWaitMax, config.RetryWaitMin)
+	}
+	httpClient, err := newHTTPClient(config.Transport)
 	if err != nil {
 		return nil, err
 	}
-	c.setHeaders(req)
-	hc := http.Client{}
-	resp, err := hc.Do(req)
-	defer resp.Body.Close()
+	return &typesenseClient{
+		key:          config.Key,
+		endpoint:     strings.TrimSuffix(endpoint.String(), "/"),
+		userAgent:    userAgent(config.ProviderVersion, config.TerraformVersion),
+		httpClient:   httpClient,
+		maxRetries:   config.MaxRetries,
+		retryWaitMin: config.RetryWaitMin,
+		retryWaitMax: config.RetryWaitMax,
+		limiter:      newRateLimiter(config.MaxRequestsPerSecond, config.MaxConcurrentRequests),
+	}, nil
+}
+
+type typesenseClient struct {
+	key        string
+	endpoint   string
+	userAgent  string
+	httpClient *http.Client
+
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	limiter *rateLimiter
+}
+
+// clustersURL joins the clusters collection URL with the given path segments.
+func (c *typesenseClient) clustersURL(segments ...string) string {
+	u := c.endpoint + "/clusters"
+	for _, segment := range segments {
+		u += "/" + url.PathEscape(segment)
+	}
+	return u
+}
+
+func (c *typesenseClient) GetCluster(ctx context.Context, id string) (*typesenseCluster, error) {
+	body, err := c.do(ctx, http.MethodGet, c.clustersURL(id), nil)
 	if err != nil {
 		return nil, err
 	}
-	body, err := io.ReadAll(resp.Body)
 	var cluster typesenseCluster
-	if err = json.Unmarshal(body, &cluster); err != nil {
+	if err = decodeResponse(body, &cluster); err != nil {
 		return nil, err
 	}
 	return &cluster, nil
+}
+
+// ListClusters returns every cluster of the account, requesting as many
+// pages as needed.
+func (c *typesenseClient) ListClusters(ctx context.Context) ([]typesenseCluster, error) {
+	var clusters []typesenseCluster
+	for page := 1; ; page++ {
+		query := url.Values{
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(clustersPerPage)},
+		}
+		body, err := c.do(ctx, http.MethodGet, c.clustersURL()+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var response typesenseClusterListResponse
+		if err = decodeResponse(body, &response); err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, response.Clusters...)
+		// A short page is the last one. The total, when the API reports
+		// it, saves requesting an empty page after a full one.
+		perPage := clustersPerPage
+		if response.PerPage > 0 {
+			perPage = response.PerPage
+		}
+		if len(response.Clusters) < perPage || (response.Total > 0 && len(clusters) >= response.Total) {
+			return clusters, nil
+		}
+	}
+}
+
+// clustersNamed returns the clusters with the given name that are not
+// terminated, since names can be reused once a cluster is gone.
+func clustersNamed(ctx context.Context, client CloudClient, name string) ([]typesenseCluster, error) {
+	clusters, err := client.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var named []typesenseCluster
+	for _, cluster := range clusters {
+		if cluster.Name == name && !cluster.isTerminated() {
+			named = append(named, cluster)
+		}
+	}
+	return named, nil
+}
 
+// nodeCount returns the number of nodes in the cluster, counting its
+// hostnames when the API doesn't report it.
+func (tc *typesenseCluster) nodeCount() int64 {
+	if tc.NodeCount > 0 {
+		return tc.NodeCount
+	}
+	return int64(len(tc.Hostnames.Nodes))
 }
 
-func (c *typesenseClient) CreateCluster(model typesenseCluster) (*typesenseCluster, error) {
+// isTerminated reports whether the cluster is gone or on its way out.
+func (tc *typesenseCluster) isTerminated() bool {
+	return tc.Status == clusterStatusTerminating || tc.Status == clusterStatusTerminated
+}
+
+func (c *typesenseClient) CreateCluster(ctx context.Context, model typesenseCluster) (*typesenseCluster, error) {
 	params := map[string]interface{}{
 		"memory":                  model.Memory,
 		"vcpu":                    model.VCPU,
 		"regions":                 model.Regions,
 		"high_availability":       model.HighAvailability,
-		"search_delivery_network": "off",
+		"search_delivery_network": model.SearchDeliveryNetwork,
 		"high_performance_disk":   model.HighPerformanceDisk,
 		"name":                    model.Name,
 		"auto_upgrade_capacity":   model.AutoUpgradeCapacity,
 	}
-	payload, _ := json.Marshal(params)
-	req, err := http.NewRequest("POST", clusterEndpoint, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, err
+	if model.LoadBalancing != "" {
+		params["load_balancing"] = model.LoadBalancing
 	}
-	c.setHeaders(req)
-	hc := http.Client{}
-	resp, err := hc.Do(req)
-	defer resp.Body.Close()
+	if model.NodeCount != 0 {
+		params["node_count"] = model.NodeCount
+	}
+	if model.SearchDeliveryNetwork == "" {
+		params["search_delivery_network"] = "off"
+	}
+	if model.TypesenseServerVersion != "" {
+		params["typesense_server_version"] = model.TypesenseServerVersion
+	}
+	payload, _ := json.Marshal(params)
+	body, err := c.do(ctx, http.MethodPost, c.clustersURL(), payload)
 	if err != nil {
 		return nil, err
 	}
-	body, err := io.ReadAll(resp.Body)
 	var response typesenseClusterCreateResponse
-	if err = json.Unmarshal(body, &response); err != nil {
+	if err = decodeResponse(body, &response); err != nil {
 		return nil, err
 	}
 	if !response.Success {
-		return nil, errors.New(string(body))
+		return nil, newAPIError(http.StatusOK, nil, body)
 	}
 	return &response.Cluster, nil
 }
 
-func (c *typesenseClient) UpdateCluster(model typesenseCluster) error {
+func (c *typesenseClient) UpdateCluster(ctx context.Context, model typesenseCluster) error {
 	params := map[string]interface{}{
 		"auto_upgrade_capacity": model.AutoUpgradeCapacity,
 		"name":                  model.Name,
 	}
 	payload, _ := json.Marshal(params)
-	req, err := http.NewRequest("PATCH", clusterEndpoint+"/"+model.ID, bytes.NewBuffer(payload))
-	if err != nil {
-		return err
-	}
-	c.setHeaders(req)
-	hc := http.Client{}
-	resp, err := hc.Do(req)
-	defer resp.Body.Close()
+	body, err := c.do(ctx, http.MethodPatch, c.clustersURL(model.ID), payload)
 	if err != nil {
 		return err
 	}
-	body, err := io.ReadAll(resp.Body)
 	var response typesenseClusterCreateResponse
-	if err = json.Unmarshal(body, &response); err != nil {
+	if err = decodeResponse(body, &response); err != nil {
 		return err
 	}
 	if !response.Success {
-		return errors.New(string(body))
+		return newAPIError(http.StatusOK, nil, body)
 	}
 	return nil
 }
 
-func (c *typesenseClient) TerminateCluster(id string) error {
+func (c *typesenseClient) TerminateCluster(ctx context.Context, id string) error {
 	params := map[string]interface{}{
 		"lifecycle_action": "terminate",
 	}
 	payload, _ := json.Marshal(params)
-	req, err := http.NewRequest("POST", clusterEndpoint+"/"+id+"/lifecycle", bytes.NewBuffer(payload))
-	if err != nil {
-		return err
-	}
-	c.setHeaders(req)
-	hc := http.Client{}
-	resp, err := hc.Do(req)
-	defer resp.Body.Close()
+	body, err := c.do(ctx, http.MethodPost, c.clustersURL(id, "lifecycle"), payload)
 	if err != nil {
 		return err
 	}
-	body, err := io.ReadAll(resp.Body)
 	var response typesenseClusterCreateResponse
-	if err = json.Unmarshal(body, &response); err != nil {
+	if err = decodeResponse(body, &response); err != nil {
 		return err
 	}
 	if !response.Success {
-		return errors.New(string(body))
+		return newAPIError(http.StatusOK, nil, body)
 	}
 	return nil
 }
 
+// apiKeyHeader carries the Cloud Management API key on every request.
+const apiKeyHeader = "X-TYPESENSE-CLOUD-MANAGEMENT-API-KEY"
+
 func (c *typesenseClient) setHeaders(req *http.Request) {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
-	req.Header.Add("X-TYPESENSE-CLOUD-MANAGEMENT-API-KEY", c.key)
+	req.Header.Add(apiKeyHeader, c.key)
+	req.Header.Set("User-Agent", c.userAgent)
+}
+
+// userAgent builds the User-Agent header sent with every request, for example
+// "terraform-provider-typesense/1.2.3 terraform/1.6.0".
+func userAgent(providerVersion, terraformVersion string) string {
+	if providerVersion == "" {
+		providerVersion = "dev"
+	}
+	ua := "terraform-provider-typesense/" + providerVersion
+	if terraformVersion != "" {
+		ua += " terraform/" + terraformVersion
+	}
+	return ua
+}
+
+// logContext adds the request fields to ctx and masks every credential the
+// client may log, so that TF_LOG=trace output can be shared safely.
+func (c *typesenseClient) logContext(ctx context.Context, method, url string) context.Context {
+	ctx = tflog.SetField(ctx, "http_method", method)
+	ctx = tflog.SetField(ctx, "http_url", url)
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, headerLogKey(apiKeyHeader))
+	ctx = tflog.MaskLogRegexes(ctx, secretFieldPattern)
+	if c.key != "" {
+		ctx = tflog.MaskLogStrings(ctx, c.key)
+	}
+	return ctx
+}
+
+// headerLogKey returns the log field key used for an HTTP header.
+func headerLogKey(name string) string {
+	return "http_header_" + strings.ReplaceAll(strings.ToLower(name), "-", "_")
+}
+
+// do sends a request to the Cloud Management API and returns the response body.
+// Rate limited and refused requests are retried with exponential backoff until
+// maxRetries is exhausted or ctx is done. Server side failures and dropped
+// connections are only retried for idempotent methods: the API may already
+// have acted on a POST, and sending it again could create a duplicate cluster,
+// configuration change or API key.
+func (c *typesenseClient) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	ctx = c.logContext(ctx, method, url)
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		c.setHeaders(req)
+
+		reqFields := map[string]interface{}{
+			"retry_count":       attempt,
+			"http_request_body": string(payload),
+		}
+		for name := range req.Header {
+			reqFields[headerLogKey(name)] = req.Header.Get(name)
+		}
+		queued := time.Now()
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if wait := time.Since(queued); wait > 100*time.Millisecond {
+			tflog.Debug(ctx, "Cloud Management API request delayed by client-side rate limit", map[string]interface{}{
+				"retry_count":     attempt,
+				"rate_limit_wait": wait.String(),
+			})
+		}
+		tflog.Trace(ctx, "Sending Cloud Management API request", reqFields)
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			release()
+			tflog.Debug(ctx, "Cloud Management API request failed", map[string]interface{}{
+				"retry_count": attempt,
+				"duration_ms": time.Since(start).Milliseconds(),
+				"error":       err.Error(),
+			})
+			if attempt < c.maxRetries && ctx.Err() == nil && shouldRetryError(method, err) {
+				if err = c.retryAfter(ctx, attempt, c.backoff(attempt, nil), err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		release()
+		if err != nil {
+			if attempt < c.maxRetries && ctx.Err() == nil && shouldRetryError(method, err) {
+				if err = c.retryAfter(ctx, attempt, c.backoff(attempt, nil), err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+		tflog.Debug(ctx, "Received Cloud Management API response", map[string]interface{}{
+			"retry_count": attempt,
+			"duration_ms": time.Since(start).Milliseconds(),
+			"http_status": resp.StatusCode,
+		})
+		tflog.Trace(ctx, "Cloud Management API response body", map[string]interface{}{
+			"http_status":        resp.StatusCode,
+			"http_response_body": string(body),
+		})
+
+		if shouldRetryStatus(method, resp.StatusCode) && attempt < c.maxRetries {
+			if err = c.retryAfter(ctx, attempt, c.backoff(attempt, resp), http.StatusText(resp.StatusCode)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return nil, newAPIError(resp.StatusCode, resp.Header, body)
+		}
+		return body, nil
+	}
+}
+
+// retryAfter logs the upcoming retry and waits for d.
+func (c *typesenseClient) retryAfter(ctx context.Context, attempt int, d time.Duration, reason string) error {
+	tflog.Debug(ctx, "Retrying Cloud Management API request", map[string]interface{}{
+		"retry_count": attempt + 1,
+		"retry_wait":  d.String(),
+		"reason":      reason,
+	})
+	return sleep(ctx, d)
+}
+
+// backoff returns how long to wait before retrying the given attempt. A
+// Retry-After header sent by the API takes precedence over the computed delay.
+func (c *typesenseClient) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if d > c.retryWaitMax {
+				return c.retryWaitMax
+			}
+			return d
+		}
+	}
+	d := c.retryWaitMin << uint(attempt)
+	if d <= 0 || d > c.retryWaitMax {
+		d = c.retryWaitMax
+	}
+	// Equal jitter: keep half of the delay and randomise the other half so that
+	// concurrent operations don't retry in lockstep.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// sleep pauses for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isIdempotent reports whether sending the request again has the same effect
+// as sending it once.
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodDelete
+}
+
+// shouldRetryStatus reports whether a request answered with status is sent
+// again. A rate limited request was rejected before the API acted on it.
+func shouldRetryStatus(method string, status int) bool {
+	return status == http.StatusTooManyRequests || (isIdempotent(method) && isRetryableStatus(status))
+}
+
+// shouldRetryError reports whether a request that failed with err is sent
+// again. A refused connection never reached the API.
+func shouldRetryError(method string, err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) || (isIdempotent(method) && isRetryableError(err))
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || (status >= 500 && status != http.StatusNotImplemented)
+}
+
+func isRetryableError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 type typesenseClusterApiKeys struct {
@@ -170,28 +578,155 @@ type typesenseClusterApiKeysCreateResponse struct {
 	ClusterApiKeys typesenseClusterApiKeys `json:"api_keys"`
 }
 
-func (c *typesenseClient) CreateClusterApiKeys(model typesenseClusterApiKeys) (*typesenseClusterApiKeys, error) {
-	clusterApiKeysEndpoint := clusterEndpoint + "/" + model.ClusterId + "/api-keys"
-	req, err := http.NewRequest("POST", clusterApiKeysEndpoint, nil)
+func (c *typesenseClient) CreateClusterApiKeys(ctx context.Context, model typesenseClusterApiKeys) (*typesenseClusterApiKeys, error) {
+	body, err := c.do(ctx, http.MethodPost, c.clustersURL(model.ClusterId, "api-keys"), nil)
 	if err != nil {
 		return nil, err
 	}
-	c.setHeaders(req)
-	hc := http.Client{}
-	resp, err := hc.Do(req)
-	defer resp.Body.Close()
-	if err != nil {
-		return nil, err
-	}
-	body, err := io.ReadAll(resp.Body)
 	var response typesenseClusterApiKeysCreateResponse
-	if err = json.Unmarshal(body, &response); err != nil {
+	if err = decodeResponse(body, &response); err != nil {
 		return nil, err
 	}
 	if !response.Success {
-		return nil, errors.New(string(body))
+		return nil, newAPIError(http.StatusOK, nil, body)
 	}
 	response.ClusterApiKeys.ClusterId = model.ClusterId
 	response.ClusterApiKeys.Id = model.ClusterId
 	return &response.ClusterApiKeys, nil
 }
+
+// Configuration change statuses reported by the Cloud Management API.
+const (
+	configurationChangeStatusScheduled  = "scheduled"
+	configurationChangeStatusInProgress = "in_progress"
+	configurationChangeStatusCompleted  = "completed"
+	configurationChangeStatusCancelled  = "cancelled"
+	configurationChangeStatusFailed     = "failed"
+)
+
+// typesenseConfigurationChange is a resize or upgrade of a cluster, applied
+// either immediately or at PerformChangeAt.
+type typesenseConfigurationChange struct {
+	ID                        string `json:"id"`
+	ClusterID                 string `json:"cluster_id"`
+	NewMemory                 string `json:"new_memory,omitempty"`
+	NewVCPU                   string `json:"new_vcpu,omitempty"`
+	NewHighPerformanceDisk    string `json:"new_high_performance_disk,omitempty"`
+	NewTypesenseServerVersion string `json:"new_typesense_server_version,omitempty"`
+	// PerformChangeAt is the Unix time the change is scheduled at, zero to apply it right away.
+	PerformChangeAt int64  `json:"perform_change_at,omitempty"`
+	Status          string `json:"status"`
+	CompletedAt     int64  `json:"completed_at,omitempty"`
+}
+
+// isPending reports whether the change has not been applied yet.
+func (cc *typesenseConfigurationChange) isPending() bool {
+	return cc.Status == configurationChangeStatusScheduled || cc.Status == configurationChangeStatusInProgress
+}
+
+// isEmpty reports whether the change leaves the cluster as it is.
+func (cc *typesenseConfigurationChange) isEmpty() bool {
+	return cc.NewMemory == "" && cc.NewVCPU == "" && cc.NewHighPerformanceDisk == "" && cc.NewTypesenseServerVersion == ""
+}
+
+// sameAs reports whether cc already performs other. Once a change is in
+// progress its schedule no longer matters.
+func (cc *typesenseConfigurationChange) sameAs(other typesenseConfigurationChange) bool {
+	if cc.NewMemory != other.NewMemory || cc.NewVCPU != other.NewVCPU ||
+		cc.NewHighPerformanceDisk != other.NewHighPerformanceDisk ||
+		cc.NewTypesenseServerVersion != other.NewTypesenseServerVersion {
+		return false
+	}
+	return cc.Status == configurationChangeStatusInProgress || cc.PerformChangeAt == other.PerformChangeAt
+}
+
+type typesenseConfigurationChangeCreateResponse struct {
+	Success             bool                         `json:"success"`
+	ConfigurationChange typesenseConfigurationChange `json:"configuration_change"`
+}
+
+type typesenseConfigurationChangeListResponse struct {
+	ConfigurationChanges []typesenseConfigurationChange `json:"configuration_changes"`
+}
+
+func (c *typesenseClient) CreateConfigurationChange(ctx context.Context, model typesenseConfigurationChange) (*typesenseConfigurationChange, error) {
+	params := map[string]interface{}{}
+	if model.NewMemory != "" {
+		params["new_memory"] = model.NewMemory
+	}
+	if model.NewVCPU != "" {
+		params["new_vcpu"] = model.NewVCPU
+	}
+	if model.NewHighPerformanceDisk != "" {
+		params["new_high_performance_disk"] = model.NewHighPerformanceDisk
+	}
+	if model.NewTypesenseServerVersion != "" {
+		params["new_typesense_server_version"] = model.NewTypesenseServerVersion
+	}
+	if model.PerformChangeAt != 0 {
+		params["perform_change_at"] = model.PerformChangeAt
+	}
+	payload, _ := json.Marshal(params)
+	body, err := c.do(ctx, http.MethodPost, c.clustersURL(model.ClusterID, "configuration-changes"), payload)
+	if err != nil {
+		return nil, err
+	}
+	var response typesenseConfigurationChangeCreateResponse
+	if err = decodeResponse(body, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, newAPIError(http.StatusOK, nil, body)
+	}
+	if response.ConfigurationChange.ClusterID == "" {
+		response.ConfigurationChange.ClusterID = model.ClusterID
+	}
+	return &response.ConfigurationChange, nil
+}
+
+func (c *typesenseClient) GetConfigurationChange(ctx context.Context, clusterID, id string) (*typesenseConfigurationChange, error) {
+	body, err := c.do(ctx, http.MethodGet, c.clustersURL(clusterID, "configuration-changes", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var change typesenseConfigurationChange
+	if err = decodeResponse(body, &change); err != nil {
+		return nil, err
+	}
+	if change.ClusterID == "" {
+		change.ClusterID = clusterID
+	}
+	return &change, nil
+}
+
+func (c *typesenseClient) ListConfigurationChanges(ctx context.Context, clusterID string) ([]typesenseConfigurationChange, error) {
+	body, err := c.do(ctx, http.MethodGet, c.clustersURL(clusterID, "configuration-changes"), nil)
+	if err != nil {
+		return nil, err
+	}
+	var response typesenseConfigurationChangeListResponse
+	if err = decodeResponse(body, &response); err != nil {
+		return nil, err
+	}
+	for i := range response.ConfigurationChanges {
+		if response.ConfigurationChanges[i].ClusterID == "" {
+			response.ConfigurationChanges[i].ClusterID = clusterID
+		}
+	}
+	return response.ConfigurationChanges, nil
+}
+
+func (c *typesenseClient) CancelConfigurationChange(ctx context.Context, clusterID, id string) error {
+	body, err := c.do(ctx, http.MethodDelete, c.clustersURL(clusterID, "configuration-changes", id), nil)
+	if err != nil {
+		return err
+	}
+	var response typesenseClusterCreateResponse
+	if err = decodeResponse(body, &response); err != nil {
+		return err
+	}
+	if !response.Success {
+		return newAPIError(http.StatusOK, nil, body)
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package typesense
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"terraform-provider-typesense/internal/fakecloud"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func newTestClient(t *testing.T, maxRetries int) *typesenseClient {
+	t.Helper()
+	client, err := NewClient(clientConfig{
+		Key:          "test",
+		MaxRetries:   maxRetries,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestClientRetriesRetryableStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"id":"abc"}`))
+		}
+	}))
+	defer server.Close()
+
+	body, err := newTestClient(t, 3).do(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(body) != `{"id":"abc"}` {
+		t.Errorf("unexpected body %q", body)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(t, 2).do(context.Background(), http.MethodGet, server.URL, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestClientDoesNotRetryNonIdempotentRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(t, 3).do(context.Background(), http.MethodPost, server.URL, []byte(`{}`)); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected the POST to be sent once, got %d calls", calls)
+	}
+}
+
+func TestClientRetriesRateLimitedNonIdempotentRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(t, 3).do(context.Background(), http.MethodPost, server.URL, []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestClientStopsRetryingWhenContextIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(clientConfig{Key: "test", MaxRetries: 5, RetryWaitMax: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.do(ctx, http.MethodGet, server.URL, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request was not cancelled, took %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("7"); !ok || d != 7*time.Second {
+		t.Errorf("unexpected result for seconds: %s %t", d, ok)
+	}
+	if d, ok := parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)); !ok || d != 0 {
+		t.Errorf("unexpected result for past date: %s %t", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected malformed header to be ignored")
+	}
+}
+
+func TestClientEndpoint(t *testing.T) {
+	client, err := NewClient(clientConfig{Key: "test", Endpoint: "http://localhost:8080/api/v1/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := client.clustersURL("abc", "lifecycle"), "http://localhost:8080/api/v1/clusters/abc/lifecycle"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	client, err = NewClient(clientConfig{Key: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := client.clustersURL(), defaultEndpoint+"/clusters"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := NewClient(clientConfig{Key: "test", Endpoint: "cloud.typesense.org"}); err == nil {
+		t.Error("expected relative endpoint to be rejected")
+	}
+}
+
+func TestClientLogsMaskSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"api_keys":{"admin_key":"admin-s3cr3t","search_only_key":"search-s3cr3t"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(clientConfig{Key: "management-s3cr3t", Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if _, err := client.CreateClusterApiKeys(ctx, typesenseClusterApiKeys{ClusterId: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected the request to be logged")
+	}
+	for _, secret := range []string{"management-s3cr3t", "admin-s3cr3t", "search-s3cr3t"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log output leaks %q", secret)
+		}
+	}
+	var sawStatus bool
+	for _, entry := range entries {
+		if entry["http_status"] == float64(http.StatusOK) {
+			sawStatus = true
+		}
+	}
+	if !sawStatus {
+		t.Error("expected the response status to be logged")
+	}
+}
+
+func TestClientUserAgent(t *testing.T) {
+	var ua string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(clientConfig{Key: "test", Endpoint: server.URL, ProviderVersion: "1.2.3", TerraformVersion: "1.6.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetCluster(context.Background(), "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if ua != "terraform-provider-typesense/1.2.3 terraform/1.6.0" {
+		t.Errorf("unexpected User-Agent %q", ua)
+	}
+}
+
+func newFakeCloudClient(t *testing.T) (*fakecloud.Server, *typesenseClient) {
+	t.Helper()
+	fake := fakecloud.NewServer(testKey)
+	t.Cleanup(fake.Close)
+	client, err := NewClient(clientConfig{
+		Key:          testKey,
+		Endpoint:     fake.URL,
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, client
+}
+
+func TestClientClusterLifecycle(t *testing.T) {
+	fake, client := newFakeCloudClient(t)
+	ctx := context.Background()
+
+	cluster, err := client.CreateCluster(ctx, typesenseCluster{
+		Name:    "test",
+		Memory:  "0.5_gb",
+		VCPU:    "2_vcpus_1_hr_burst_per_day",
+		Regions: []string{"oregon"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Status != fakecloud.StatusInitializing {
+		t.Errorf("expected a new cluster to be initializing, got %q", cluster.Status)
+	}
+	if cluster, err = client.GetCluster(ctx, cluster.ID); err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Status != clusterStatusInService {
+		t.Errorf("expected the cluster to be in service, got %q", cluster.Status)
+	}
+
+	keys, err := client.CreateClusterApiKeys(ctx, typesenseClusterApiKeys{ClusterId: cluster.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.AdminKey == "" || keys.SearchOnlyKey == "" {
+		t.Error("expected api keys to be returned")
+	}
+
+	change, err := client.CreateConfigurationChange(ctx, typesenseConfigurationChange{ClusterID: cluster.ID, NewMemory: "1_gb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change, err = client.GetConfigurationChange(ctx, cluster.ID, change.ID); err != nil {
+		t.Fatal(err)
+	}
+	if change.Status != configurationChangeStatusCompleted {
+		t.Errorf("expected the change to be completed, got %q", change.Status)
+	}
+	if stored, _ := fake.Cluster(cluster.ID); stored.Memory != "1_gb" {
+		t.Errorf("expected the cluster to be resized, got %q", stored.Memory)
+	}
+
+	if err := client.TerminateCluster(ctx, cluster.ID); err != nil {
+		t.Fatal(err)
+	}
+	if cluster, err = client.GetCluster(ctx, cluster.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !cluster.isTerminated() {
+		t.Errorf("expected the cluster to be terminated, got %q", cluster.Status)
+	}
+}
+
+func TestClientRecoversFromInjectedFaults(t *testing.T) {
+	fake, client := newFakeCloudClient(t)
+	cluster := fake.AddCluster(fakecloud.Cluster{Memory: "0.5_gb", VCPU: "2_vcpus_1_hr_burst_per_day", Regions: []string{"oregon"}})
+
+	fake.InjectFault(fakecloud.Fault{Status: http.StatusTooManyRequests, RetryAfter: "0"}, 1)
+	fake.InjectFault(fakecloud.Fault{Status: http.StatusInternalServerError, Method: http.MethodGet}, 2)
+	if _, err := client.GetCluster(context.Background(), cluster.ID); err != nil {
+		t.Fatalf("expected the request to be retried, got %s", err)
+	}
+	if requests := fake.Requests(); requests != 4 {
+		t.Errorf("expected 4 requests, got %d", requests)
+	}
+
+	fake.InjectFault(fakecloud.Fault{Status: http.StatusInternalServerError}, 10)
+	if _, err := client.GetCluster(context.Background(), cluster.ID); err == nil {
+		t.Error("expected persistent failures to be reported")
+	}
+}
+
+func TestClientHonoursLatency(t *testing.T) {
+	fake, client := newFakeCloudClient(t)
+	fake.Latency = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetCluster(ctx, "abc"); err == nil {
+		t.Error("expected the slow request to be cancelled")
+	}
+}
+
+func TestClientListClustersPages(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		clusters  int
+		omitTotal bool
+	}{
+		{name: "with total", clusters: 5},
+		{name: "without total", clusters: 5, omitTotal: true},
+		{name: "full last page without total", clusters: 4, omitTotal: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake, client := newFakeCloudClient(t)
+			fake.MaxPerPage = 2
+			fake.OmitTotal = tc.omitTotal
+			for i := 0; i < tc.clusters; i++ {
+				fake.AddCluster(fakecloud.Cluster{Name: fmt.Sprintf("cluster-%d", i)})
+			}
+
+			clusters, err := client.ListClusters(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen := map[string]bool{}
+			for _, cluster := range clusters {
+				seen[cluster.ID] = true
+			}
+			if len(clusters) != tc.clusters || len(seen) != tc.clusters {
+				t.Errorf("expected %d distinct clusters, got %d clusters", tc.clusters, len(clusters))
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package typesense
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -12,8 +14,8 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &clusterResource{}
-	_ resource.ResourceWithConfigure = &clusterResource{}
+	_ resource.Resource              = &clusterApiKeysResource{}
+	_ resource.ResourceWithConfigure = &clusterApiKeysResource{}
 
 	clusterApiKeysResourceSchema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -58,15 +60,23 @@ func NewClusterApiKeysResource() resource.Resource {
 
 // clusterApiKeysResource is the resource implementation.
 type clusterApiKeysResource struct {
-	client *typesenseClient
+	client CloudClient
 }
 
 // Configure adds the provider configured client to the resource.
-func (cr *clusterApiKeysResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (cr *clusterApiKeysResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	cr.client = req.ProviderData.(*typesenseClient)
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *typesense.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	cr.client = data.client
 }
 
 // Metadata returns the resource type name.
@@ -90,16 +100,32 @@ func (cr *clusterApiKeysResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// Create new cluster
-	clusterApiKeys, err := cr.client.CreateClusterApiKeys(typesenseClusterApiKeys{
+	clusterApiKeys, err := cr.client.CreateClusterApiKeys(ctx, typesenseClusterApiKeys{
 		Id:            plan.ID.ValueString(),
 		ClusterId:     plan.ClusterId.ValueString(),
 		AdminKey:      plan.AdminKey.ValueString(),
 		SearchOnlyKey: plan.SearchOnlyKey.ValueString(),
 	})
+	if IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cluster_id"),
+			"Typesense Cluster Not Found",
+			"Could not create cluster api keys, cluster "+plan.ClusterId.ValueString()+" does not exist.\n\n"+err.Error(),
+		)
+		return
+	}
+	if IsConflict(err) {
+		resp.Diagnostics.AddError(
+			"Typesense Cluster Not Ready",
+			"Could not create cluster api keys, cluster "+plan.ClusterId.ValueString()+" is not ready to accept new keys. "+
+				"Wait until the cluster is in service and apply again.\n\n"+err.Error(),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cluster api keys",
-			"Could not create cluster api keys, unexpected error: "+err.Error(),
+			"Could not create cluster api keys, unexpected error: "+describeError(err),
 		)
 		return
 	}
@@ -2,16 +2,22 @@ package typesense
 
 import (
 	"fmt"
-	"os"
+	"terraform-provider-typesense/internal/fakecloud"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-var testClusterId = os.Getenv("CLUSTER_ID")
-
 func TestClusterApiKeysResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	fake, providerConfig := newFakeCloud(t)
+	cluster := fake.AddCluster(fakecloud.Cluster{
+		Memory:  "0.5_gb",
+		VCPU:    "2_vcpus_1_hr_burst_per_day",
+		Regions: []string{"oregon"},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create testing
@@ -20,9 +26,9 @@ func TestClusterApiKeysResource(t *testing.T) {
 resource "typesense_cluster_api_keys" "test" {
 	cluster_id = "%s"
 }
-`, testClusterId),
+`, cluster.ID),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("typesense_cluster_api_keys.test", "cluster_id", testClusterId),
+					resource.TestCheckResourceAttr("typesense_cluster_api_keys.test", "cluster_id", cluster.ID),
 					resource.TestCheckResourceAttrSet("typesense_cluster_api_keys.test", "admin_key"),
 					resource.TestCheckResourceAttrSet("typesense_cluster_api_keys.test", "search_only_key"),
 				),
@@ -0,0 +1,385 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &clusterConfigurationChangeResource{}
+	_ resource.ResourceWithConfigure        = &clusterConfigurationChangeResource{}
+	_ resource.ResourceWithValidateConfig   = &clusterConfigurationChangeResource{}
+	_ resource.ResourceWithConfigValidators = &clusterConfigurationChangeResource{}
+	_ resource.ResourceWithImportState      = &clusterConfigurationChangeResource{}
+
+	clusterConfigurationChangeResourceSchema = schema.Schema{
+		Description: "Resizes or upgrades a cluster through a configuration change, either immediately or at perform_change_at. " +
+			"A configuration change cannot be modified, changing any argument other than timeouts replaces it. " +
+			"When changes are managed with this resource, add memory, vcpu, high_performance_disk and typesense_server_version " +
+			"to lifecycle.ignore_changes of the typesense_cluster resource so that it doesn't revert them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Autogenerated ID assigned by the Typesense engine.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "The cluster id.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_memory": schema.StringAttribute{
+				Description: "How much RAM the cluster should have after the change. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#memory",
+				Optional:    true,
+				Validators: []validator.String{
+					catalogValidator{kind: "Memory", values: catalog.memoryValues()},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_vcpu": schema.StringAttribute{
+				Description: "How many CPU cores the cluster should have after the change. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#vcpu",
+				Optional:    true,
+				Validators: []validator.String{
+					catalogValidator{kind: "vCPU", values: catalog.vcpuValues()},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_high_performance_disk": schema.StringAttribute{
+				Description: "Whether the cluster should use a high performance disk after the change, yes or no.",
+				Optional:    true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"yes", "no"}},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_typesense_server_version": schema.StringAttribute{
+				Description: "Typesense server version the cluster should be upgraded to.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"perform_change_at": schema.StringAttribute{
+				Description: "Time at which the change is performed, as an RFC 3339 timestamp such as 2023-06-04T02:00:00Z. When unset, the change is applied immediately and Terraform waits for it to complete.",
+				Optional:    true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the configuration change: scheduled, in_progress, completed, cancelled or failed.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(configurationChangeTimeouts),
+		},
+	}
+)
+
+// configurationChangeTimeouts are the default timeouts of configuration change operations.
+var configurationChangeTimeouts = map[string]time.Duration{
+	timeoutCreate: 60 * time.Minute,
+}
+
+// NewClusterConfigurationChangeResource is a helper function to simplify the provider implementation.
+func NewClusterConfigurationChangeResource() resource.Resource {
+	return &clusterConfigurationChangeResource{}
+}
+
+// clusterConfigurationChangeResource is the resource implementation.
+type clusterConfigurationChangeResource struct {
+	client CloudClient
+	poll   pollConfig
+}
+
+// Configure adds the provider configured client to the resource.
+func (ccr *clusterConfigurationChangeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *typesense.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	ccr.client = data.client
+	ccr.poll = data.poll
+}
+
+// Metadata returns the resource type name.
+func (ccr *clusterConfigurationChangeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_configuration_change"
+}
+
+// Schema defines the schema for the resource.
+func (ccr *clusterConfigurationChangeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = clusterConfigurationChangeResourceSchema
+}
+
+// ConfigValidators checks that the new memory, vcpu and high performance disk
+// can be combined according to the embedded catalog, when they are all set.
+func (ccr *clusterConfigurationChangeResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		clusterCompatibilityValidator{
+			memory:              "new_memory",
+			vcpu:                "new_vcpu",
+			highPerformanceDisk: "new_high_performance_disk",
+		},
+	}
+}
+
+// ValidateConfig ensures the configuration change changes something.
+func (ccr *clusterConfigurationChangeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config typesenseClusterConfigurationChangeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, value := range []types.String{config.NewMemory, config.NewVCPU, config.NewHighPerformanceDisk, config.NewTypesenseServerVersion} {
+		if !value.IsNull() {
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Missing Configuration Change",
+		"At least one of new_memory, new_vcpu, new_high_performance_disk or new_typesense_server_version must be set.",
+	)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (ccr *clusterConfigurationChangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan typesenseClusterConfigurationChangeModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, timeout := withTimeout(ctx, plan.Timeouts, timeoutCreate, configurationChangeTimeouts)
+	defer cancel()
+
+	change := typesenseConfigurationChange{
+		ClusterID:                 plan.ClusterID.ValueString(),
+		NewMemory:                 plan.NewMemory.ValueString(),
+		NewVCPU:                   plan.NewVCPU.ValueString(),
+		NewHighPerformanceDisk:    plan.NewHighPerformanceDisk.ValueString(),
+		NewTypesenseServerVersion: plan.NewTypesenseServerVersion.ValueString(),
+	}
+	if !plan.PerformChangeAt.IsNull() {
+		// The value was validated at plan time.
+		performChangeAt, _ := time.Parse(time.RFC3339, plan.PerformChangeAt.ValueString())
+		change.PerformChangeAt = performChangeAt.Unix()
+	}
+
+	created, err := ccr.client.CreateConfigurationChange(ctx, change)
+	if IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cluster_id"),
+			"Typesense Cluster Not Found",
+			"Could not create configuration change, cluster "+plan.ClusterID.ValueString()+" does not exist.\n\n"+err.Error(),
+		)
+		return
+	}
+	if IsConflict(err) {
+		resp.Diagnostics.AddError(
+			"Typesense Cluster Not Ready",
+			"Could not create configuration change, cluster "+plan.ClusterID.ValueString()+" is not in service or another configuration change is pending. "+
+				"Wait for the cluster to settle and apply again.\n\n"+err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Configuration Change",
+			"Could not create configuration change, unexpected error: "+describeError(err),
+		)
+		return
+	}
+
+	// Immediate changes are applied before Terraform moves on, scheduled
+	// ones are only tracked.
+	if created.Status != configurationChangeStatusScheduled {
+		tflog.Info(ctx, "Waiting for Typesense cluster configuration change", map[string]any{
+			"id":        created.ClusterID,
+			"change_id": created.ID,
+		})
+		created, err = waitForConfigurationChange(ctx, ccr.client, ccr.poll, created)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating Configuration Change",
+				fmt.Sprintf("Configuration change %s did not complete (create timeout %s): %s", created.ID, timeout, describeError(err)),
+			)
+			return
+		}
+	}
+	plan.refresh(created)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (ccr *clusterConfigurationChangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state typesenseClusterConfigurationChangeModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	change, err := ccr.client.GetConfigurationChange(ctx, state.ClusterID.ValueString(), state.ID.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "Typesense cluster configuration change no longer exists, removing it from state", map[string]any{
+			"id":        state.ClusterID.ValueString(),
+			"change_id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Configuration Change",
+			"Could not read configuration change "+state.ID.ValueString()+": "+describeError(err),
+		)
+		return
+	}
+	// A change cancelled outside of Terraform is removed from state so that
+	// the next plan requests it again.
+	if change.Status == configurationChangeStatusCancelled {
+		tflog.Warn(ctx, "Typesense cluster configuration change was cancelled outside of Terraform, removing it from state", map[string]any{
+			"id":        change.ClusterID,
+			"change_id": change.ID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.refresh(change)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores new timeouts: every other argument requires replacement,
+// configuration changes are never modified through the API.
+func (ccr *clusterConfigurationChangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state typesenseClusterConfigurationChangeModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Status = state.Status
+
+	// Set state to fully populated data
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete cancels the configuration change if it is still scheduled. Changes
+// that already started or completed cannot be undone and are only removed
+// from the Terraform state.
+func (ccr *clusterConfigurationChangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state typesenseClusterConfigurationChangeModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	change, err := ccr.client.GetConfigurationChange(ctx, state.ClusterID.ValueString(), state.ID.ValueString())
+	if IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Configuration Change",
+			"Could not read configuration change "+state.ID.ValueString()+": "+describeError(err),
+		)
+		return
+	}
+	if change.Status != configurationChangeStatusScheduled {
+		tflog.Info(ctx, "Typesense cluster configuration change is no longer scheduled, removing it from state only", map[string]any{
+			"id":        change.ClusterID,
+			"change_id": change.ID,
+			"status":    change.Status,
+		})
+		return
+	}
+
+	err = ccr.client.CancelConfigurationChange(ctx, change.ClusterID, change.ID)
+	if IsNotFound(err) {
+		return
+	}
+	if IsConflict(err) {
+		// The change started between the read and the cancellation.
+		resp.Diagnostics.AddWarning(
+			"Configuration Change Not Cancelled",
+			"Configuration change "+change.ID+" started before it could be cancelled and is applied to the cluster. It was removed from the Terraform state.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Configuration Change",
+			"Could not cancel configuration change "+change.ID+": "+describeError(err),
+		)
+		return
+	}
+}
+
+// ImportState imports a configuration change from a "<cluster_id>/<change_id>" identifier.
+func (ccr *clusterConfigurationChangeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, changeID, ok := strings.Cut(req.ID, "/")
+	if !ok || clusterID == "" || changeID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected an import ID of the form <cluster_id>/<change_id>, got: "+req.ID,
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), changeID)...)
+}
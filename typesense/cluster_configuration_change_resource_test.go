@@ -0,0 +1,151 @@
+package typesense
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-typesense/internal/fakecloud"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestClusterConfigurationChangeResource(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	cluster := fake.AddCluster(fakecloud.Cluster{
+		Memory:  "0.5_gb",
+		VCPU:    "2_vcpus_1_hr_burst_per_day",
+		Regions: []string{"oregon"},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			changes := fake.ConfigurationChanges(cluster.ID)
+			if got := changes[len(changes)-1].Status; got != fakecloud.ChangeCancelled {
+				return fmt.Errorf("expected the scheduled change to be cancelled on destroy, got %s", got)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// A change without arguments is rejected
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster_configuration_change" "test" {
+  cluster_id = "%s"
+}
+`, cluster.ID),
+				ExpectError: regexp.MustCompile("Missing Configuration Change"),
+			},
+			// Options that cannot be combined are rejected
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster_configuration_change" "test" {
+  cluster_id = "%s"
+  new_memory = "64_gb"
+  new_vcpu = "2_vcpus_1_hr_burst_per_day"
+}
+`, cluster.ID),
+				ExpectError: regexp.MustCompile("Incompatible vCPU"),
+			},
+			// Immediate change testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster_configuration_change" "test" {
+  cluster_id = "%s"
+  new_memory = "1_gb"
+}
+`, cluster.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("typesense_cluster_configuration_change.test", "id"),
+					resource.TestCheckResourceAttr("typesense_cluster_configuration_change.test", "status", "completed"),
+					func(*terraform.State) error {
+						if got, _ := fake.Cluster(cluster.ID); got.Memory != "1_gb" {
+							return fmt.Errorf("expected the cluster to be resized, cluster has memory %s", got.Memory)
+						}
+						return nil
+					},
+				),
+			},
+			// Scheduled change testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster_configuration_change" "test" {
+  cluster_id = "%s"
+  new_vcpu = "2_vcpus_2_hr_burst_per_day"
+  perform_change_at = "2099-01-01T04:00:00+02:00"
+}
+`, cluster.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster_configuration_change.test", "status", "scheduled"),
+					resource.TestCheckResourceAttr("typesense_cluster_configuration_change.test", "perform_change_at", "2099-01-01T04:00:00+02:00"),
+					func(*terraform.State) error {
+						if got, _ := fake.Cluster(cluster.ID); got.VCPU != "2_vcpus_1_hr_burst_per_day" {
+							return fmt.Errorf("expected the resize to be scheduled, cluster has vcpu %s", got.VCPU)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "typesense_cluster_configuration_change.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["typesense_cluster_configuration_change.test"]
+					return rs.Primary.Attributes["cluster_id"] + "/" + rs.Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"perform_change_at"},
+			},
+			// Timeouts are updated in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster_configuration_change" "test" {
+  cluster_id = "%s"
+  new_vcpu = "2_vcpus_2_hr_burst_per_day"
+  perform_change_at = "2099-01-01T04:00:00+02:00"
+
+  timeouts {
+    create = "1h"
+  }
+}
+`, cluster.ID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_cluster_configuration_change.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("typesense_cluster_configuration_change.test", "timeouts.create", "1h"),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster_configuration_change" "test" {
+  cluster_id = "%s"
+  new_vcpu = "2_vcpus_2_hr_burst_per_day"
+  perform_change_at = "2099-01-01T04:00:00+02:00"
+
+  timeouts {
+    create = "2h"
+  }
+}
+`, cluster.ID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_cluster_configuration_change.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster_configuration_change.test", "timeouts.create", "2h"),
+					resource.TestCheckResourceAttr("typesense_cluster_configuration_change.test", "status", "scheduled"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -2,27 +2,32 @@ package typesense
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &clusterDataSource{}
-	_ datasource.DataSourceWithConfigure = &clusterDataSource{}
+	_ datasource.DataSource                   = &clusterDataSource{}
+	_ datasource.DataSourceWithConfigure      = &clusterDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &clusterDataSource{}
 
 	clusterDataSourceSchema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Autogenerated ID assigned by the Typesense engine.",
-				Required:    true,
+				Description: "Autogenerated ID assigned by the Typesense engine. Either id or name must be set to look up the cluster.",
+				Computed:    true,
+				Optional:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "A string to identify the cluster for your reference in the Typesense Cloud Web console.",
+				Description: "A string to identify the cluster for your reference in the Typesense Cloud Web console. Either id or name must be set to look up the cluster, the name must match exactly one cluster that is not terminated.",
 				Computed:    true,
+				Optional:    true,
 			},
 			"memory": schema.StringAttribute{
 				Description: "How much RAM this cluster has.",
@@ -37,22 +42,37 @@ var (
 				Computed:    true,
 			},
 			"typesense_server_version": schema.StringAttribute{
-				Description: "Cluster Typesense server version at creation time.",
+				Description: "Typesense server version the cluster runs.",
+				Computed:    true,
+			},
+			"current_typesense_server_version": schema.StringAttribute{
+				Description: "Typesense server version the cluster is actually running. Same as typesense_server_version, kept for parity with the typesense_cluster resource.",
 				Computed:    true,
 			},
 			"high_availability": schema.StringAttribute{
 				Description: "When set to yes, cluster is HA and your data is automatically replicated between all nodes.",
 				Computed:    true,
 			},
+			"node_count": schema.Int64Attribute{
+				Description: "Number of nodes in the cluster.",
+				Computed:    true,
+			},
 			"search_delivery_network": schema.StringAttribute{
 				Description: "When not off, nodes are provisioned in different regions and the node that's closest to it's originating location serves the traffic.",
 				Computed:    true,
 			},
 			"load_balancing": schema.StringAttribute{
-				Computed: true,
+				Description: "When set to yes, a load balanced hostname distributes requests between the nodes of the cluster.",
+				Computed:    true,
 			},
 			"region": schema.StringAttribute{
-				Description: "Region where the nodes are geographically placed.",
+				Description:        "First region where the nodes are geographically placed.",
+				DeprecationMessage: "Use regions instead, clusters with Search Delivery Network span several regions.",
+				Computed:           true,
+			},
+			"regions": schema.ListAttribute{
+				Description: "Regions where the nodes are geographically placed.",
+				ElementType: types.StringType,
 				Computed:    true,
 			},
 			"auto_upgrade_capacity": schema.BoolAttribute{
@@ -71,6 +91,10 @@ var (
 						Description: "Load balancer hostname if load balancing is enabled.",
 						Computed:    true,
 					},
+					"nearest_node": schema.StringAttribute{
+						Description: "Hostname routing requests to the node closest to their origin if Search Delivery Network is enabled.",
+						Computed:    true,
+					},
 					"nodes": schema.ListAttribute{
 						Description: "List of nodes in the cluster.",
 						ElementType: types.StringType,
@@ -78,6 +102,44 @@ var (
 					},
 				},
 			},
+			"estimated_monthly_cost": schema.Float64Attribute{
+				Description: fmt.Sprintf("Estimated monthly cost of the cluster in %s, from the prices embedded in the provider. Null when no price is known for the configuration.", pricing.Currency),
+				Computed:    true,
+			},
+			"pending_configuration_change": schema.SingleNestedAttribute{
+				Description: "Configuration change scheduled or in progress on the cluster.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Description: "ID of the configuration change.",
+						Computed:    true,
+					},
+					"status": schema.StringAttribute{
+						Description: "Status of the configuration change, scheduled or in_progress.",
+						Computed:    true,
+					},
+					"perform_change_at": schema.StringAttribute{
+						Description: "Time at which the change is performed.",
+						Computed:    true,
+					},
+					"new_memory": schema.StringAttribute{
+						Description: "Memory the cluster is resized to.",
+						Computed:    true,
+					},
+					"new_vcpu": schema.StringAttribute{
+						Description: "CPU cores the cluster is resized to.",
+						Computed:    true,
+					},
+					"new_high_performance_disk": schema.StringAttribute{
+						Description: "High performance disk setting the cluster is changed to.",
+						Computed:    true,
+					},
+					"new_typesense_server_version": schema.StringAttribute{
+						Description: "Typesense server version the cluster is upgraded to.",
+						Computed:    true,
+					},
+				},
+			},
 		},
 	}
 )
@@ -87,7 +149,7 @@ func NewClusterDataSource() datasource.DataSource {
 }
 
 type clusterDataSource struct {
-	client *typesenseClient
+	client CloudClient
 }
 
 func (cds *clusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -100,61 +162,120 @@ func (cds *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 
 func (cds *clusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Get current state
-	var config typesenseClusterModel
+	var config typesenseClusterDataSourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	cluster, err := cds.client.GetCluster(config.ID.ValueString())
+	if !config.Name.IsNull() {
+		cds.readByName(ctx, config.Name.ValueString(), resp)
+		return
+	}
+	cluster, err := cds.client.GetCluster(ctx, config.ID.ValueString())
+	if IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Typesense Cluster Not Found",
+			"No cluster with ID "+config.ID.ValueString()+" is visible to this Cloud Management API key.\n\n"+err.Error(),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read Typesense cluster",
-			err.Error(),
+			describeError(err),
 		)
 		return
 	}
 
-	nodes := make([]attr.Value, len(cluster.Hostnames.Nodes))
-	for i, node := range cluster.Hostnames.Nodes {
-		nodes[i] = types.StringValue(node)
-	}
+	cds.setState(ctx, cluster, resp)
+}
 
-	hostnames := map[string]attr.Value{
-		"load_balanced": types.StringValue(cluster.Hostnames.LoadBalanced),
-		"nodes":         types.ListValueMust(types.StringType, nodes),
+// readByName looks up the only cluster named name that is not terminated.
+func (cds *clusterDataSource) readByName(ctx context.Context, name string, resp *datasource.ReadResponse) {
+	clusters, err := clustersNamed(ctx, cds.client, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list Typesense clusters",
+			describeError(err),
+		)
+		return
 	}
+	switch len(clusters) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Typesense Cluster Not Found",
+			fmt.Sprintf("No cluster named %q is visible to this Cloud Management API key.", name),
+		)
+	case 1:
+		cds.setState(ctx, &clusters[0], resp)
+	default:
+		ids := make([]string, len(clusters))
+		for i, cluster := range clusters {
+			ids[i] = cluster.ID
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Ambiguous Typesense Cluster Name",
+			fmt.Sprintf("%d clusters are named %q: %s. Look the cluster up by id instead.", len(clusters), name, strings.Join(ids, ", ")),
+		)
+	}
+}
 
-	tcm := &typesenseClusterModel{
-		ID:                     types.StringValue(cluster.ID),
-		Name:                   types.StringValue(cluster.Name),
-		Memory:                 types.StringValue(cluster.Memory),
-		VCPU:                   types.StringValue(cluster.VCPU),
-		HighPerformanceDisk:    types.StringValue(cluster.HighPerformanceDisk),
-		TypesenseServerVersion: types.StringValue(cluster.TypesenseServerVersion),
-		HighAvailability:       types.StringValue(cluster.HighAvailability),
-		SearchDeliveryNetwork:  types.StringValue(cluster.SearchDeliveryNetwork),
-		LoadBalancing:          types.StringValue(cluster.LoadBalancing),
-		Region:                 types.StringValue(cluster.Regions[0]),
-		AutoUpgradeCapacity:    types.BoolValue(cluster.AutoUpgradeCapacity),
-		Status:                 types.StringValue(cluster.Status),
-		Hostnames: types.ObjectValueMust(map[string]attr.Type{
-			"load_balanced": types.StringType,
-			"nodes":         types.ListType{ElemType: types.StringType},
-		}, hostnames),
+// setState saves cluster and its pending configuration change as the data source state.
+func (cds *clusterDataSource) setState(ctx context.Context, cluster *typesenseCluster, resp *datasource.ReadResponse) {
+	pending, err := pendingConfigurationChange(ctx, cds.client, cluster.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Typesense cluster configuration changes",
+			describeError(err),
+		)
+		return
 	}
+
+	var tcm typesenseClusterDataSourceModel
+	tcm.refresh(cluster, pending)
 	// Set state
-	diags = resp.State.Set(ctx, tcm)
+	diags := resp.State.Set(ctx, &tcm)
 	resp.Diagnostics.Append(diags...)
+}
+
+// ValidateConfig ensures the cluster is looked up by exactly one of id and name.
+func (cds *clusterDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config typesenseClusterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	switch {
+	case config.ID.IsNull() && config.Name.IsNull():
+		resp.Diagnostics.AddError(
+			"Missing Cluster Lookup Key",
+			"Either id or name must be set to look up the cluster.",
+		)
+	case !config.ID.IsNull() && !config.Name.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Conflicting Cluster Lookup Keys",
+			"Only one of id and name can be set to look up the cluster.",
+		)
+	}
 }
 
 // Configure adds the provider configured client to the data source.
-func (cds *clusterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+func (cds *clusterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	cds.client = req.ProviderData.(*typesenseClient)
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *typesense.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	cds.client = data.client
 }
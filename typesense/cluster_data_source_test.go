@@ -1,13 +1,36 @@
 package typesense
 
 import (
+	"regexp"
+	"terraform-provider-typesense/internal/fakecloud"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-func TestAccCoffeesDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+func TestClusterDataSource(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	fake.AddCluster(fakecloud.Cluster{
+		ID:                     "05umtgeli2v8b19np",
+		Name:                   "sandbox",
+		Memory:                 "0.5_gb",
+		VCPU:                   "2_vcpus_1_hr_burst_per_day",
+		HighPerformanceDisk:    "no",
+		TypesenseServerVersion: "0.24.1",
+		HighAvailability:       "no",
+		SearchDeliveryNetwork:  "off",
+		LoadBalancing:          "no",
+		Regions:                []string{"n_california"},
+		Status:                 fakecloud.StatusInService,
+		Hostnames:              fakecloud.Hostnames{Nodes: []string{"05umtgeli2v8b19np-1.a1.typesense.net"}},
+	})
+
+	for i := 0; i < 2; i++ {
+		fake.AddCluster(fakecloud.Cluster{Name: "twin", Regions: []string{"oregon"}})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
@@ -26,12 +49,56 @@ data "typesense_cluster" "test" {
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "memory", "0.5_gb"),
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "name", "sandbox"),
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "region", "n_california"),
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "regions.#", "1"),
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "search_delivery_network", "off"),
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "status", "in_service"),
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "typesense_server_version", "0.24.1"),
 					resource.TestCheckResourceAttr("data.typesense_cluster.test", "vcpu", "2_vcpus_1_hr_burst_per_day"),
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "hostnames.nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "node_count", "1"),
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "estimated_monthly_cost", "7.81"),
+					resource.TestCheckNoResourceAttr("data.typesense_cluster.test", "pending_configuration_change.id"),
+				),
+			},
+			// Lookup by name testing
+			{
+				Config: providerConfig + `
+data "typesense_cluster" "test" {
+  name = "sandbox"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "id", "05umtgeli2v8b19np"),
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "name", "sandbox"),
+					resource.TestCheckResourceAttr("data.typesense_cluster.test", "memory", "0.5_gb"),
 				),
 			},
+			{
+				Config: providerConfig + `
+data "typesense_cluster" "test" {}`,
+				ExpectError: regexp.MustCompile("Missing Cluster Lookup Key"),
+			},
+			{
+				Config: providerConfig + `
+data "typesense_cluster" "test" {
+  id = "05umtgeli2v8b19np"
+  name = "sandbox"
+}`,
+				ExpectError: regexp.MustCompile("Conflicting Cluster Lookup Keys"),
+			},
+			{
+				Config: providerConfig + `
+data "typesense_cluster" "test" {
+  name = "missing"
+}`,
+				ExpectError: regexp.MustCompile(`No cluster named "missing"`),
+			},
+			{
+				Config: providerConfig + `
+data "typesense_cluster" "test" {
+  name = "twin"
+}`,
+				ExpectError: regexp.MustCompile(`2 clusters are named "twin"`),
+			},
 		},
 	})
 }
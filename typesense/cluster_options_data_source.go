@@ -0,0 +1,118 @@
+package typesense
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &clusterOptionsDataSource{}
+
+	clusterOptionsDataSourceSchema = schema.Schema{
+		Description: "Lists the memory tiers, vCPU options, regions and Typesense server versions clusters can be created with, and which of them can be combined. " +
+			"The catalog is embedded in the provider, as the Cloud Management API doesn't publish it, and is the one typesense_cluster and typesense_cluster_configuration_change configurations are validated against. " +
+			"Options Typesense Cloud added after the provider was released are missing until the provider is updated.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier of the catalog.",
+				Computed:    true,
+			},
+			"memory_options": schema.ListNestedAttribute{
+				Description: "Memory tiers, from smallest to largest.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "Value of the memory attribute of a cluster, such as 2_gb.",
+							Computed:    true,
+						},
+						"vcpu": schema.ListAttribute{
+							Description: "vCPU options available with this amount of memory.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"vcpu_options": schema.ListNestedAttribute{
+				Description: "vCPU options.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "Value of the vcpu attribute of a cluster, such as 2_vcpus.",
+							Computed:    true,
+						},
+						"burst": schema.BoolAttribute{
+							Description: "Whether the CPU only runs at full speed for a few hours per day.",
+							Computed:    true,
+						},
+						"high_performance_disk": schema.BoolAttribute{
+							Description: "Whether a high performance disk is available with this option.",
+							Computed:    true,
+						},
+						"high_availability": schema.BoolAttribute{
+							Description: "Whether high availability is available with this option.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"regions": schema.ListNestedAttribute{
+				Description: "Regions nodes can be placed in.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Description: "Value of an element of the regions attribute of a cluster, such as oregon.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Human readable location of the region.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"typesense_server_versions": schema.ListAttribute{
+				Description: "Typesense server versions clusters can run, from oldest to newest.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+)
+
+func NewClusterOptionsDataSource() datasource.DataSource {
+	return &clusterOptionsDataSource{}
+}
+
+type clusterOptionsDataSource struct{}
+
+func (cds *clusterOptionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_options"
+}
+
+func (cds *clusterOptionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = clusterOptionsDataSourceSchema
+}
+
+func (cds *clusterOptionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config typesenseClusterOptionsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ID = types.StringValue("cluster_options")
+	config.refresh(catalog)
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
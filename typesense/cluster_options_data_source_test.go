@@ -0,0 +1,35 @@
+package typesense
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestClusterOptionsDataSource(t *testing.T) {
+	_, providerConfig := newFakeCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Embedded catalog testing
+			{
+				Config: providerConfig + `
+data "typesense_cluster_options" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "memory_options.#", strconv.Itoa(len(catalog.Memory))),
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "memory_options.0.value", "0.5_gb"),
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "memory_options.0.vcpu.0", "2_vcpus_1_hr_burst_per_day"),
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "vcpu_options.#", strconv.Itoa(len(catalog.VCPU))),
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "vcpu_options.0.burst", "true"),
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "regions.#", strconv.Itoa(len(catalog.Regions))),
+					resource.TestCheckResourceAttrSet("data.typesense_cluster_options.test", "regions.0.name"),
+					resource.TestCheckResourceAttr("data.typesense_cluster_options.test", "typesense_server_versions.#", strconv.Itoa(len(catalog.TypesenseServerVersions))),
+				),
+			},
+		},
+	})
+}
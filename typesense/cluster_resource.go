@@ -2,27 +2,42 @@ package typesense
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &clusterResource{}
-	_ resource.ResourceWithConfigure = &clusterResource{}
+	_ resource.Resource                     = &clusterResource{}
+	_ resource.ResourceWithConfigure        = &clusterResource{}
+	_ resource.ResourceWithModifyPlan       = &clusterResource{}
+	_ resource.ResourceWithValidateConfig   = &clusterResource{}
+	_ resource.ResourceWithConfigValidators = &clusterResource{}
+	_ resource.ResourceWithUpgradeState     = &clusterResource{}
 
 	clusterResourceSchema = schema.Schema{
+		Description: "Manages a Typesense Cloud cluster. Memory, vCPU, high performance disk and server version are changed in place through configuration changes. " +
+			"Typesense Cloud cannot change the high availability, node count, load balancing, Search Delivery Network or regions of an existing cluster: " +
+			"changing any of them destroys the cluster and creates a new one, and all its data is lost. Set deletion_protection so that applying such a change fails instead.",
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Autogenerated ID assigned by the Typesense engine.",
@@ -37,62 +52,107 @@ var (
 				Optional:    true,
 			},
 			"memory": schema.StringAttribute{
-				Description: "How much RAM this cluster should have. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#memory",
+				Description: "How much RAM this cluster should have. Changing it resizes the cluster in place through a configuration change. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#memory",
 				Required:    true,
+				Validators: []validator.String{
+					catalogValidator{kind: "Memory", values: catalog.memoryValues()},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"vcpu": schema.StringAttribute{
-				Description: "How many CPU cores this cluster should have. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#vcpu",
+				Description: "How many CPU cores this cluster should have. Changing it resizes the cluster in place through a configuration change. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#vcpu",
 				Required:    true,
+				Validators: []validator.String{
+					catalogValidator{kind: "vCPU", values: catalog.vcpuValues()},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"high_performance_disk": schema.StringAttribute{
-				Description: "When set to yes, the provisioned hard disk will be co-located on the same physical server that runs the node.",
+				Description: "When set to yes, the provisioned hard disk will be co-located on the same physical server that runs the node. Changing it updates the cluster in place through a configuration change.",
 				Computed:    true,
 				Default:     stringdefault.StaticString("no"),
 				Optional:    true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"yes", "no"}},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"typesense_server_version": schema.StringAttribute{
-				Description: "Cluster Typesense server version at creation time.",
+				Description: "Typesense server version the cluster should run. Defaults to the latest version at creation time. Raising it upgrades the cluster through a configuration change, downgrades are not supported.",
+				Computed:    true,
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current_typesense_server_version": schema.StringAttribute{
+				Description: "Typesense server version the cluster is actually running.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"high_availability": schema.StringAttribute{
-				Description: "When set to yes, at least 3 nodes are provisioned in 3 different data centers to form a highly available (HA) cluster and your data is automatically replicated between all nodes.",
+				Description: "When set to yes, at least 3 nodes are provisioned in 3 different data centers to form a highly available (HA) cluster and your data is automatically replicated between all nodes." + replacesCluster,
 				Computed:    true,
 				Default:     stringdefault.StaticString("no"),
 				Optional:    true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"yes", "no"}},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"node_count": schema.Int64Attribute{
+				Description: "Number of nodes in the cluster. Must be 1 without high availability, and an odd number of at least 3 with it so that nodes can reach a quorum. With Search Delivery Network there must be at least one node per region. Defaults to 1, or 3 with high availability." + replacesCluster,
+				Computed:    true,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"search_delivery_network": schema.StringAttribute{
-				Description: "When not off, nodes are provisioned in different regions and the node that's closest to it's originating location serves the traffic.",
+				Description: "Search Delivery Network mode, off or automatic. When automatic, nodes are provisioned in each of the regions and the node that's closest to a request's originating location serves the traffic. Defaults to off." + replacesCluster,
 				Computed:    true,
+				Default:     stringdefault.StaticString("off"),
+				Optional:    true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"off", "automatic"}},
+				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"load_balancing": schema.StringAttribute{
-				Computed: true,
+				Description: "When set to yes, a load balanced hostname distributes requests between the nodes of a highly available cluster. Requires high_availability. Defaults to yes with high availability, no otherwise." + replacesCluster,
+				Computed:    true,
+				Optional:    true,
+				Validators: []validator.String{
+					oneOfValidator{values: []string{"yes", "no"}},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"region": schema.StringAttribute{
-				Description: "Region where the nodes should be geographically placed. Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#regions",
+			"regions": schema.ListAttribute{
+				Description: "Regions where the nodes should be geographically placed. Exactly one region unless search_delivery_network is enabled." + replacesCluster + " Available options here: https://typesense.org/docs/cloud-management-api/v1/cluster-management.html#regions",
+				ElementType: types.StringType,
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+				Validators: []validator.List{
+					catalogValidator{kind: "Region", values: catalog.regionValues()},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
 				},
 			},
 			"auto_upgrade_capacity": schema.BoolAttribute{
@@ -101,6 +161,22 @@ var (
 				Default:     booldefault.StaticBool(false),
 				Optional:    true,
 			},
+			"wait_for_termination": schema.BoolAttribute{
+				Description: "When set to true, destroying the cluster waits until Typesense Cloud reports it terminated, within the delete timeout. Set to false to return as soon as the termination is requested.",
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Optional:    true,
+			},
+			"estimated_monthly_cost": schema.Float64Attribute{
+				Description: fmt.Sprintf("Estimated monthly cost of the cluster in %s, from the prices embedded in the provider. Actual invoices also depend on bandwidth and taxes. Null when no price is known for the configuration.", pricing.Currency),
+				Computed:    true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "When set to true, destroying or replacing the cluster fails instead of terminating it. Set it to false and apply before destroying the cluster. Defaults to false.",
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Optional:    true,
+			},
 			"status": schema.StringAttribute{
 				Description: "Current status of your cluster.",
 				Computed:    true,
@@ -119,6 +195,13 @@ var (
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"nearest_node": schema.StringAttribute{
+						Description: "Hostname routing requests to the node closest to their origin if Search Delivery Network is enabled.",
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
 					"nodes": schema.ListAttribute{
 						Description: "List of nodes in the cluster.",
 						ElementType: types.StringType,
@@ -132,10 +215,65 @@ var (
 					objectplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"perform_change_at": schema.StringAttribute{
+				Description: "Time at which changes to memory, vcpu, high_performance_disk and typesense_server_version are performed, as an RFC 3339 timestamp such as 2023-06-04T02:00:00Z. Use it to resize or upgrade production clusters off-peak. When unset or in the past, changes are applied immediately.",
+				Optional:    true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"pending_configuration_change": schema.SingleNestedAttribute{
+				Description: "Configuration change scheduled or in progress on the cluster. While a change is pending, memory, vcpu, high_performance_disk and typesense_server_version report its target values.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Description: "ID of the configuration change.",
+						Computed:    true,
+					},
+					"status": schema.StringAttribute{
+						Description: "Status of the configuration change, scheduled or in_progress.",
+						Computed:    true,
+					},
+					"perform_change_at": schema.StringAttribute{
+						Description: "Time at which the change is performed.",
+						Computed:    true,
+					},
+					"new_memory": schema.StringAttribute{
+						Description: "Memory the cluster is resized to.",
+						Computed:    true,
+					},
+					"new_vcpu": schema.StringAttribute{
+						Description: "CPU cores the cluster is resized to.",
+						Computed:    true,
+					},
+					"new_high_performance_disk": schema.StringAttribute{
+						Description: "High performance disk setting the cluster is changed to.",
+						Computed:    true,
+					},
+					"new_typesense_server_version": schema.StringAttribute{
+						Description: "Typesense server version the cluster is upgraded to.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(clusterTimeouts),
 		},
 	}
 )
 
+// replacesCluster ends the description of the attributes Typesense Cloud
+// cannot change on an existing cluster.
+const replacesCluster = " Changing it destroys the cluster and creates a new one: all the data of the cluster is lost."
+
+// clusterTimeouts are the default timeouts of cluster operations.
+var clusterTimeouts = map[string]time.Duration{
+	timeoutCreate: 60 * time.Minute,
+	timeoutUpdate: 60 * time.Minute,
+	timeoutDelete: 30 * time.Minute,
+}
+
 // NewClusterResource is a helper function to simplify the provider implementation.
 func NewClusterResource() resource.Resource {
 	return &clusterResource{}
@@ -143,15 +281,28 @@ func NewClusterResource() resource.Resource {
 
 // clusterResource is the resource implementation.
 type clusterResource struct {
-	client *typesenseClient
+	client CloudClient
+	poll   pollConfig
+	// maxMonthlyCost is the budget of each cluster, no limit when zero.
+	maxMonthlyCost float64
 }
 
 // Configure adds the provider configured client to the resource.
-func (cr *clusterResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (cr *clusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	cr.client = req.ProviderData.(*typesenseClient)
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *typesense.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	cr.client = data.client
+	cr.poll = data.poll
+	cr.maxMonthlyCost = data.maxMonthlyCost
 }
 
 // Metadata returns the resource type name.
@@ -174,70 +325,64 @@ func (cr *clusterResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	ctx, cancel, timeout := withTimeout(ctx, plan.Timeouts, timeoutCreate, clusterTimeouts)
+	defer cancel()
+
+	var regions []string
+	resp.Diagnostics.Append(plan.Regions.ElementsAs(ctx, &regions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create new cluster
-	cluster, err := cr.client.CreateCluster(typesenseCluster{
-		Memory:                plan.Memory.ValueString(),
-		VCPU:                  plan.VCPU.ValueString(),
-		Regions:               []string{plan.Region.ValueString()},
-		HighAvailability:      plan.HighAvailability.ValueString(),
-		SearchDeliveryNetwork: plan.SearchDeliveryNetwork.ValueString(),
-		HighPerformanceDisk:   plan.HighPerformanceDisk.ValueString(),
-		Name:                  plan.Name.ValueString(),
-		AutoUpgradeCapacity:   plan.AutoUpgradeCapacity.ValueBool(),
+	cluster, err := cr.client.CreateCluster(ctx, typesenseCluster{
+		Memory:                 plan.Memory.ValueString(),
+		VCPU:                   plan.VCPU.ValueString(),
+		TypesenseServerVersion: plan.TypesenseServerVersion.ValueString(),
+		Regions:                regions,
+		HighAvailability:       plan.HighAvailability.ValueString(),
+		NodeCount:              plan.NodeCount.ValueInt64(),
+		LoadBalancing:          plan.LoadBalancing.ValueString(),
+		SearchDeliveryNetwork:  plan.SearchDeliveryNetwork.ValueString(),
+		HighPerformanceDisk:    plan.HighPerformanceDisk.ValueString(),
+		Name:                   plan.Name.ValueString(),
+		AutoUpgradeCapacity:    plan.AutoUpgradeCapacity.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cluster",
-			"Could not create cluster, unexpected error: "+err.Error(),
+			"Could not create cluster, unexpected error: "+describeError(err),
 		)
 		return
 	}
 
-	// Waiting until state is not provisioning.
-	clusterId := cluster.ID
-	for {
-		time.Sleep(8 * time.Second)
-		cluster, err = cr.client.GetCluster(clusterId)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error waiting for cluster state",
-				"Cluster created, but could not reach expected state: "+err.Error(),
-			)
-			return
-		}
-		if cluster.Status != "in_service" {
-			continue
+	// Wait until the cluster is provisioned.
+	tflog.Info(ctx, "Waiting for Typesense cluster to be in service", map[string]any{
+		"id":      cluster.ID,
+		"timeout": timeout.String(),
+	})
+	created := cluster
+	cluster, err = waitForCluster(ctx, cr.client, cr.poll, created.ID)
+	if err != nil {
+		// Keep the cluster in state so that Terraform taints it instead of
+		// losing track of it.
+		if cluster == nil {
+			cluster = created
 		}
-		break
-	}
-
-	plan.ID = types.StringValue(cluster.ID)
-	plan.Name = types.StringValue(cluster.Name)
-	plan.Memory = types.StringValue(cluster.Memory)
-	plan.VCPU = types.StringValue(cluster.VCPU)
-	plan.HighPerformanceDisk = types.StringValue(cluster.HighPerformanceDisk)
-	plan.TypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
-	plan.HighAvailability = types.StringValue(cluster.HighAvailability)
-	plan.SearchDeliveryNetwork = types.StringValue(cluster.SearchDeliveryNetwork)
-	plan.LoadBalancing = types.StringValue(cluster.LoadBalancing)
-	plan.Region = types.StringValue(cluster.Regions[0])
-	plan.AutoUpgradeCapacity = types.BoolValue(cluster.AutoUpgradeCapacity)
-	plan.Status = types.StringValue(cluster.Status)
-
-	nodes := make([]attr.Value, len(cluster.Hostnames.Nodes))
-	for i, node := range cluster.Hostnames.Nodes {
-		nodes[i] = types.StringValue(node)
-	}
-
-	hostnames := map[string]attr.Value{
-		"load_balanced": types.StringValue(cluster.Hostnames.LoadBalanced),
-		"nodes":         types.ListValueMust(types.StringType, nodes),
+		plan.refresh(cluster)
+		plan.trackPendingChange(nil)
+		plan.estimateCost()
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.AddError(
+			"Error waiting for cluster state",
+			fmt.Sprintf("Cluster %s was created but did not become %s (create timeout %s): %s", created.ID, clusterStatusInService, timeout, describeError(err)),
+		)
+		return
 	}
 
-	plan.Hostnames = types.ObjectValueMust(map[string]attr.Type{
-		"load_balanced": types.StringType,
-		"nodes":         types.ListType{ElemType: types.StringType},
-	}, hostnames)
+	plan.refresh(cluster)
+	plan.trackPendingChange(nil)
+	plan.estimateCost()
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -258,41 +403,51 @@ func (cr *clusterResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Get refreshed cluster value from Typesense
-	cluster, err := cr.client.GetCluster(state.ID.ValueString())
+	cluster, err := cr.client.GetCluster(ctx, state.ID.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "Typesense cluster no longer exists, removing it from state", map[string]any{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Typesense Cluster",
-			"Could not read Typesense Cluster ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read Typesense Cluster ID "+state.ID.ValueString()+": "+describeError(err),
 		)
 		return
 	}
-	state.ID = types.StringValue(cluster.ID)
-	state.Name = types.StringValue(cluster.Name)
-	state.Memory = types.StringValue(cluster.Memory)
-	state.VCPU = types.StringValue(cluster.VCPU)
-	state.HighPerformanceDisk = types.StringValue(cluster.HighPerformanceDisk)
-	state.TypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
-	state.HighAvailability = types.StringValue(cluster.HighAvailability)
-	state.SearchDeliveryNetwork = types.StringValue(cluster.SearchDeliveryNetwork)
-	state.LoadBalancing = types.StringValue(cluster.LoadBalancing)
-	state.Region = types.StringValue(cluster.Regions[0])
-	state.AutoUpgradeCapacity = types.BoolValue(cluster.AutoUpgradeCapacity)
-	state.Status = types.StringValue(cluster.Status)
-
-	nodes := make([]attr.Value, len(cluster.Hostnames.Nodes))
-	for i, node := range cluster.Hostnames.Nodes {
-		nodes[i] = types.StringValue(node)
+	// A cluster terminated outside of Terraform is removed from state so
+	// that the next plan recreates it.
+	if cluster.isTerminated() {
+		tflog.Warn(ctx, "Typesense cluster was terminated outside of Terraform, removing it from state", map[string]any{
+			"id":     state.ID.ValueString(),
+			"status": cluster.Status,
+		})
+		resp.State.RemoveResource(ctx)
+		return
 	}
-
-	hostnames := map[string]attr.Value{
-		"load_balanced": types.StringValue(cluster.Hostnames.LoadBalanced),
-		"nodes":         types.ListValueMust(types.StringType, nodes),
+	pending, err := pendingConfigurationChange(ctx, cr.client, cluster.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Typesense Cluster",
+			"Could not read configuration changes of Typesense Cluster ID "+cluster.ID+": "+describeError(err),
+		)
+		return
+	}
+	desiredVersion := state.TypesenseServerVersion
+	state.refresh(cluster)
+	state.keepDesiredVersion(desiredVersion)
+	state.trackPendingChange(pending)
+	state.estimateCost()
+	// Imported clusters get the defaults.
+	if state.WaitForTermination.IsNull() {
+		state.WaitForTermination = types.BoolValue(true)
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
 	}
-
-	state.Hostnames = types.ObjectValueMust(map[string]attr.Type{
-		"load_balanced": types.StringType,
-		"nodes":         types.ListType{ElemType: types.StringType},
-	}, hostnames)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -312,55 +467,145 @@ func (cr *clusterResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	var state typesenseClusterModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, timeout := withTimeout(ctx, plan.Timeouts, timeoutUpdate, clusterTimeouts)
+	defer cancel()
+
 	// Update existing cluster
-	err := cr.client.UpdateCluster(typesenseCluster{
-		ID:                  plan.ID.ValueString(),
-		Name:                plan.Name.ValueString(),
-		AutoUpgradeCapacity: plan.AutoUpgradeCapacity.ValueBool(),
-	})
+	if !plan.Name.Equal(state.Name) || !plan.AutoUpgradeCapacity.Equal(state.AutoUpgradeCapacity) {
+		err := cr.client.UpdateCluster(ctx, typesenseCluster{
+			ID:                  plan.ID.ValueString(),
+			Name:                plan.Name.ValueString(),
+			AutoUpgradeCapacity: plan.AutoUpgradeCapacity.ValueBool(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Typesense Cluster",
+				"Could not update cluster, unexpected error: "+describeError(err),
+			)
+			return
+		}
+	}
+
+	// Resize or upgrade the cluster through a configuration change. The
+	// change is computed against what the cluster actually runs, so that a
+	// change still pending is accounted for.
+	cluster, err := cr.client.GetCluster(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Updating Typesense Cluster",
-			"Could not update cluster, unexpected error: "+err.Error(),
+			"Error Reading Typesense Cluster",
+			"Could not read Typesense Cluster ID "+plan.ID.ValueString()+": "+describeError(err),
 		)
 		return
 	}
-	// Get refreshed cluster value from Typesense
-	cluster, err := cr.client.GetCluster(plan.ID.ValueString())
+	pending, err := pendingConfigurationChange(ctx, cr.client, cluster.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Typesense Cluster",
-			"Could not read Typesense Cluster ID "+plan.ID.ValueString()+": "+err.Error(),
+			"Could not read configuration changes of Typesense Cluster ID "+cluster.ID+": "+describeError(err),
 		)
 		return
 	}
-	plan.ID = types.StringValue(cluster.ID)
-	plan.Name = types.StringValue(cluster.Name)
-	plan.Memory = types.StringValue(cluster.Memory)
-	plan.VCPU = types.StringValue(cluster.VCPU)
-	plan.HighPerformanceDisk = types.StringValue(cluster.HighPerformanceDisk)
-	plan.TypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
-	plan.HighAvailability = types.StringValue(cluster.HighAvailability)
-	plan.SearchDeliveryNetwork = types.StringValue(cluster.SearchDeliveryNetwork)
-	plan.LoadBalancing = types.StringValue(cluster.LoadBalancing)
-	plan.Region = types.StringValue(cluster.Regions[0])
-	plan.AutoUpgradeCapacity = types.BoolValue(cluster.AutoUpgradeCapacity)
-	plan.Status = types.StringValue(cluster.Status)
-
-	nodes := make([]attr.Value, len(cluster.Hostnames.Nodes))
-	for i, node := range cluster.Hostnames.Nodes {
-		nodes[i] = types.StringValue(node)
+	change := typesenseConfigurationChange{ClusterID: cluster.ID}
+	if plan.Memory.ValueString() != cluster.Memory {
+		change.NewMemory = plan.Memory.ValueString()
+	}
+	if plan.VCPU.ValueString() != cluster.VCPU {
+		change.NewVCPU = plan.VCPU.ValueString()
+	}
+	if plan.HighPerformanceDisk.ValueString() != cluster.HighPerformanceDisk {
+		change.NewHighPerformanceDisk = plan.HighPerformanceDisk.ValueString()
+	}
+	if !plan.TypesenseServerVersion.IsUnknown() && !plan.TypesenseServerVersion.IsNull() &&
+		compareVersions(plan.TypesenseServerVersion.ValueString(), cluster.TypesenseServerVersion) > 0 {
+		change.NewTypesenseServerVersion = plan.TypesenseServerVersion.ValueString()
+	}
+	if !plan.PerformChangeAt.IsNull() {
+		// The value was validated at plan time.
+		performChangeAt, _ := time.Parse(time.RFC3339, plan.PerformChangeAt.ValueString())
+		change.PerformChangeAt = performChangeAt.Unix()
 	}
 
-	hostnames := map[string]attr.Value{
-		"load_balanced": types.StringValue(cluster.Hostnames.LoadBalanced),
-		"nodes":         types.ListValueMust(types.StringType, nodes),
+	if pending != nil && !pending.sameAs(change) {
+		if pending.Status != configurationChangeStatusScheduled {
+			resp.Diagnostics.AddError(
+				"Configuration Change In Progress",
+				"Configuration change "+pending.ID+" is being applied to the cluster and can no longer be changed. Wait for it to complete, then apply again.",
+			)
+			return
+		}
+		tflog.Info(ctx, "Cancelling superseded Typesense cluster configuration change", map[string]any{
+			"id":        cluster.ID,
+			"change_id": pending.ID,
+		})
+		if err := cr.client.CancelConfigurationChange(ctx, cluster.ID, pending.ID); err != nil && !IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error Updating Typesense Cluster",
+				"Could not cancel configuration change "+pending.ID+": "+describeError(err),
+			)
+			return
+		}
+		pending = nil
+	}
+	if pending == nil && !change.isEmpty() {
+		if change.PerformChangeAt != 0 && change.PerformChangeAt <= time.Now().Unix() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("perform_change_at"),
+				"Configuration Change Applied Immediately",
+				"perform_change_at "+plan.PerformChangeAt.ValueString()+" is in the past, the configuration change is applied immediately.",
+			)
+			change.PerformChangeAt = 0
+		}
+		pending, err = cr.client.CreateConfigurationChange(ctx, change)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Typesense Cluster",
+				"Could not request configuration change, unexpected error: "+describeError(err),
+			)
+			return
+		}
+	}
+	if pending != nil && pending.Status != configurationChangeStatusScheduled {
+		tflog.Info(ctx, "Waiting for Typesense cluster configuration change", map[string]any{
+			"id":        cluster.ID,
+			"change_id": pending.ID,
+		})
+		if _, err = waitForConfigurationChange(ctx, cr.client, cr.poll, pending); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Typesense Cluster",
+				fmt.Sprintf("Configuration change %s did not complete (update timeout %s): %s", pending.ID, timeout, describeError(err)),
+			)
+			return
+		}
+		pending = nil
+	} else if pending != nil {
+		tflog.Info(ctx, "Typesense cluster configuration change scheduled", map[string]any{
+			"id":                cluster.ID,
+			"change_id":         pending.ID,
+			"perform_change_at": pending.PerformChangeAt,
+		})
 	}
 
-	plan.Hostnames = types.ObjectValueMust(map[string]attr.Type{
-		"load_balanced": types.StringType,
-		"nodes":         types.ListType{ElemType: types.StringType},
-	}, hostnames)
+	// Get refreshed cluster value from Typesense
+	cluster, err = cr.client.GetCluster(ctx, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Typesense Cluster",
+			"Could not read Typesense Cluster ID "+plan.ID.ValueString()+": "+describeError(err),
+		)
+		return
+	}
+	desiredVersion := plan.TypesenseServerVersion
+	plan.refresh(cluster)
+	plan.keepDesiredVersion(desiredVersion)
+	plan.trackPendingChange(pending)
+	plan.estimateCost()
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -369,6 +614,201 @@ func (cr *clusterResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 }
 
+// ConfigValidators checks that memory, vcpu, high performance disk and high
+// availability can be combined according to the embedded catalog.
+func (cr *clusterResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		clusterCompatibilityValidator{
+			memory:              "memory",
+			vcpu:                "vcpu",
+			highPerformanceDisk: "high_performance_disk",
+			highAvailability:    "high_availability",
+		},
+	}
+}
+
+// ValidateConfig checks that the regions, Search Delivery Network mode,
+// high availability, node count and load balancing form a legal topology.
+func (cr *clusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config typesenseClusterModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Regions.IsUnknown() || config.Regions.IsNull() {
+		return
+	}
+	regions := config.Regions.Elements()
+	if len(regions) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("regions"),
+			"Missing Region",
+			"At least one region must be set.",
+		)
+		return
+	}
+	seen := make(map[string]bool, len(regions))
+	for _, region := range regions {
+		if region.IsUnknown() {
+			continue
+		}
+		if seen[region.String()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("regions"),
+				"Duplicate Region",
+				"Region "+region.String()+" is listed more than once.",
+			)
+			return
+		}
+		seen[region.String()] = true
+	}
+	sdnOff := config.SearchDeliveryNetwork.IsNull() || config.SearchDeliveryNetwork.ValueString() == "off"
+	if len(regions) > 1 && sdnOff && !config.SearchDeliveryNetwork.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("regions"),
+			"Multiple Regions Require Search Delivery Network",
+			fmt.Sprintf("%d regions are set but search_delivery_network is off. A cluster without Search Delivery Network lives in a single region, either set one region or enable search_delivery_network.", len(regions)),
+		)
+	}
+	validateTopology(config, len(regions), &resp.Diagnostics)
+}
+
+// validateTopology checks the combination of high availability, node count,
+// load balancing and Search Delivery Network. Unknown values are skipped.
+func validateTopology(config typesenseClusterModel, regions int, diags *diag.Diagnostics) {
+	if config.HighAvailability.IsUnknown() {
+		return
+	}
+	ha := config.HighAvailability.ValueString() == "yes"
+	nodesSet := !config.NodeCount.IsNull() && !config.NodeCount.IsUnknown()
+	nodes := config.NodeCount.ValueInt64()
+
+	switch {
+	case !ha && nodesSet && nodes != 1:
+		diags.AddAttributeError(
+			path.Root("node_count"),
+			"Invalid Node Count",
+			fmt.Sprintf("node_count is %d but a cluster without high availability has a single node. Set high_availability to yes or node_count to 1.", nodes),
+		)
+	case ha && nodesSet && (nodes < 3 || nodes%2 == 0):
+		diags.AddAttributeError(
+			path.Root("node_count"),
+			"Invalid Node Count",
+			fmt.Sprintf("node_count is %d but a highly available cluster needs an odd number of at least 3 nodes to reach a quorum.", nodes),
+		)
+	}
+	if !ha && config.LoadBalancing.ValueString() == "yes" {
+		diags.AddAttributeError(
+			path.Root("load_balancing"),
+			"Load Balancing Requires High Availability",
+			"Load balancing distributes requests between the nodes of a highly available cluster. Set high_availability to yes or load_balancing to no.",
+		)
+	}
+	if config.SearchDeliveryNetwork.IsUnknown() || config.SearchDeliveryNetwork.IsNull() || config.SearchDeliveryNetwork.ValueString() == "off" {
+		return
+	}
+	if !ha {
+		diags.AddAttributeError(
+			path.Root("search_delivery_network"),
+			"Search Delivery Network Requires High Availability",
+			"Search Delivery Network places nodes in several regions, which requires a highly available cluster. Set high_availability to yes.",
+		)
+		return
+	}
+	if nodesSet && nodes < int64(regions) {
+		diags.AddAttributeError(
+			path.Root("node_count"),
+			"Not Enough Nodes For Regions",
+			fmt.Sprintf("node_count is %d but %d regions are set. Search Delivery Network needs at least one node per region.", nodes, regions),
+		)
+	}
+}
+
+// ModifyPlan rejects server version downgrades, which Typesense Cloud does
+// not support, and keeps the pending configuration change known when the
+// update does not touch it. The running server version is only unknown when
+// the desired version changes.
+func (cr *clusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state typesenseClusterModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cr.planCost(ctx, &plan, resp)
+	if req.State.Raw.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Memory.Equal(state.Memory) && plan.VCPU.Equal(state.VCPU) &&
+		plan.HighPerformanceDisk.Equal(state.HighPerformanceDisk) &&
+		plan.TypesenseServerVersion.Equal(state.TypesenseServerVersion) &&
+		plan.PerformChangeAt.Equal(state.PerformChangeAt) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_configuration_change"), state.PendingConfigurationChange)...)
+	}
+	if !plan.TypesenseServerVersion.Equal(state.TypesenseServerVersion) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("current_typesense_server_version"), types.StringUnknown())...)
+	}
+	// Only a version change is checked: the cluster may have been upgraded
+	// past the desired version outside of Terraform.
+	if plan.TypesenseServerVersion.IsUnknown() || plan.TypesenseServerVersion.IsNull() || state.CurrentTypesenseServerVersion.IsNull() ||
+		plan.TypesenseServerVersion.Equal(state.TypesenseServerVersion) {
+		return
+	}
+	if compareVersions(plan.TypesenseServerVersion.ValueString(), state.CurrentTypesenseServerVersion.ValueString()) < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("typesense_server_version"),
+			"Typesense Server Downgrade Not Supported",
+			fmt.Sprintf("The cluster runs Typesense %s and cannot be downgraded to %s. Set typesense_server_version to %s or a later version.",
+				state.CurrentTypesenseServerVersion.ValueString(), plan.TypesenseServerVersion.ValueString(), state.CurrentTypesenseServerVersion.ValueString()),
+		)
+	}
+}
+
+// planCost sets the estimated monthly cost of the planned cluster, and fails
+// the plan when it, added to the estimates of the other clusters of the
+// account, exceeds max_monthly_cost. Clusters created in the same plan don't
+// exist yet, so they aren't counted against each other.
+func (cr *clusterResource) planCost(ctx context.Context, plan *typesenseClusterModel, resp *resource.ModifyPlanResponse) {
+	plan.estimateCost()
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_monthly_cost"), plan.EstimatedMonthlyCost)...)
+	if cr.maxMonthlyCost == 0 || plan.EstimatedMonthlyCost.IsUnknown() || plan.EstimatedMonthlyCost.IsNull() {
+		return
+	}
+	clusters, err := cr.client.ListClusters(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list Typesense clusters",
+			"Could not list clusters to check max_monthly_cost: "+describeError(err),
+		)
+		return
+	}
+	cost := plan.EstimatedMonthlyCost.ValueFloat64()
+	total := cost
+	for _, cluster := range clusters {
+		// The state of the planned cluster holds its current cost, which
+		// the plan replaces.
+		if cluster.isTerminated() || cluster.ID == plan.ID.ValueString() {
+			continue
+		}
+		if other := cluster.estimatedMonthlyCost(); !other.IsNull() {
+			total += other.ValueFloat64()
+		}
+	}
+	if exceedsBudget(total, cr.maxMonthlyCost) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("estimated_monthly_cost"),
+			"Monthly Cost Budget Exceeded",
+			fmt.Sprintf("The clusters of the account are estimated to cost %.2f %s per month with this cluster (%.2f %s), "+
+				"which exceeds the provider max_monthly_cost of %.2f %s. "+
+				"Choose a smaller cluster, delete other clusters or raise max_monthly_cost.",
+				total, pricing.Currency, cost, pricing.Currency, cr.maxMonthlyCost, pricing.Currency),
+		)
+	}
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (cr *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
@@ -379,18 +819,266 @@ func (cr *clusterResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Typesense Cluster Deletion Protected",
+			fmt.Sprintf("Cluster %s was not terminated because deletion_protection is enabled. "+
+				"To delete it, set deletion_protection = false in the configuration, run terraform apply, then destroy or replace the cluster again.", state.ID.ValueString()),
+		)
+		return
+	}
+
+	ctx, cancel, timeout := withTimeout(ctx, state.Timeouts, timeoutDelete, clusterTimeouts)
+	defer cancel()
+
 	// Terminate cluster
-	err := cr.client.TerminateCluster(state.ID.ValueString())
+	err := cr.client.TerminateCluster(ctx, state.ID.ValueString())
+	if IsNotFound(err) {
+		// The cluster is already gone, nothing left to delete.
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Typesense Cluster",
-			"Could not delete cluster, unexpected error: "+err.Error(),
+			"Could not delete cluster, unexpected error: "+describeError(err),
 		)
 		return
 	}
+
+	if state.WaitForTermination.IsNull() || state.WaitForTermination.ValueBool() {
+		tflog.Info(ctx, "Waiting for Typesense cluster to be terminated", map[string]any{
+			"id":      state.ID.ValueString(),
+			"timeout": timeout.String(),
+		})
+		if err := waitForTermination(ctx, cr.client, cr.poll, state.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Typesense Cluster",
+				fmt.Sprintf("Termination of cluster %s was requested but did not complete (delete timeout %s): %s", state.ID.ValueString(), timeout, describeError(err)),
+			)
+			return
+		}
+	}
+}
+
+// UpgradeState upgrades the state of clusters managed by earlier versions of the provider.
+func (cr *clusterResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 had a single region attribute.
+		0: {
+			PriorSchema:   clusterResourceSchemaV0(),
+			StateUpgrader: upgradeClusterStateV0,
+		},
+	}
+}
+
+// clusterResourceSchemaV0 returns the cluster resource schema as of version
+// 0, when clusters had a single region. It must not change: it describes
+// states written by earlier versions of the provider.
+func clusterResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                       schema.StringAttribute{Computed: true},
+			"name":                     schema.StringAttribute{Computed: true, Optional: true},
+			"memory":                   schema.StringAttribute{Required: true},
+			"vcpu":                     schema.StringAttribute{Required: true},
+			"high_performance_disk":    schema.StringAttribute{Computed: true, Optional: true},
+			"typesense_server_version": schema.StringAttribute{Computed: true},
+			"high_availability":        schema.StringAttribute{Computed: true, Optional: true},
+			"search_delivery_network":  schema.StringAttribute{Computed: true},
+			"load_balancing":           schema.StringAttribute{Computed: true},
+			"region":                   schema.StringAttribute{Required: true},
+			"auto_upgrade_capacity":    schema.BoolAttribute{Computed: true, Optional: true},
+			"status":                   schema.StringAttribute{Computed: true},
+			"hostnames": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"load_balanced": schema.StringAttribute{Computed: true},
+					"nodes":         schema.ListAttribute{ElementType: types.StringType, Computed: true},
+				},
+			},
+		},
+	}
+}
+
+// typesenseClusterModelV0 maps the cluster resource schema data of version 0.
+type typesenseClusterModelV0 struct {
+	ID                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Memory                 types.String `tfsdk:"memory"`
+	VCPU                   types.String `tfsdk:"vcpu"`
+	HighPerformanceDisk    types.String `tfsdk:"high_performance_disk"`
+	TypesenseServerVersion types.String `tfsdk:"typesense_server_version"`
+	HighAvailability       types.String `tfsdk:"high_availability"`
+	SearchDeliveryNetwork  types.String `tfsdk:"search_delivery_network"`
+	LoadBalancing          types.String `tfsdk:"load_balancing"`
+	Region                 types.String `tfsdk:"region"`
+	AutoUpgradeCapacity    types.Bool   `tfsdk:"auto_upgrade_capacity"`
+	Status                 types.String `tfsdk:"status"`
+	Hostnames              types.Object `tfsdk:"hostnames"`
+}
+
+// upgradeClusterStateV0 replaces region with a regions list holding it.
+// Attributes added since version 0 are null until the next refresh.
+func upgradeClusterStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior typesenseClusterModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	regions := types.ListNull(types.StringType)
+	if !prior.Region.IsNull() {
+		var diags diag.Diagnostics
+		regions, diags = types.ListValue(types.StringType, []attr.Value{prior.Region})
+		resp.Diagnostics.Append(diags...)
+	}
+	hostnames := types.ObjectNull(hostnamesAttrTypes)
+	if !prior.Hostnames.IsNull() {
+		attributes := prior.Hostnames.Attributes()
+		var diags diag.Diagnostics
+		hostnames, diags = types.ObjectValue(hostnamesAttrTypes, map[string]attr.Value{
+			"load_balanced": attributes["load_balanced"],
+			"nearest_node":  types.StringNull(),
+			"nodes":         attributes["nodes"],
+		})
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := typesenseClusterModel{
+		ID:                            prior.ID,
+		Name:                          prior.Name,
+		Memory:                        prior.Memory,
+		VCPU:                          prior.VCPU,
+		HighPerformanceDisk:           prior.HighPerformanceDisk,
+		TypesenseServerVersion:        prior.TypesenseServerVersion,
+		CurrentTypesenseServerVersion: types.StringNull(),
+		HighAvailability:              prior.HighAvailability,
+		NodeCount:                     types.Int64Null(),
+		SearchDeliveryNetwork:         prior.SearchDeliveryNetwork,
+		LoadBalancing:                 prior.LoadBalancing,
+		Regions:                       regions,
+		AutoUpgradeCapacity:           prior.AutoUpgradeCapacity,
+		Status:                        prior.Status,
+		Hostnames:                     hostnames,
+		PerformChangeAt:               types.StringNull(),
+		PendingConfigurationChange:    types.ObjectNull(pendingChangeAttrTypes),
+		WaitForTermination:            types.BoolNull(),
+		DeletionProtection:            types.BoolNull(),
+		EstimatedMonthlyCost:          types.Float64Null(),
+		Timeouts:                      types.ObjectNull(timeoutsAttrTypes(clusterTimeouts)),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
+// clusterImportNamePrefix marks import IDs holding a cluster name rather than
+// a cluster ID, such as name:production.
+const clusterImportNamePrefix = "name:"
+
+// ImportState imports a cluster by ID, or by name when the import ID starts
+// with clusterImportNamePrefix.
 func (cr *clusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !strings.HasPrefix(req.ID, clusterImportNamePrefix) {
+		// Retrieve import ID and save to id attribute
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	name := strings.TrimPrefix(req.ID, clusterImportNamePrefix)
+	clusters, err := clustersNamed(ctx, cr.client, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Typesense Cluster",
+			"Could not list clusters to find cluster named "+name+": "+describeError(err),
+		)
+		return
+	}
+	switch len(clusters) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Typesense Cluster Not Found",
+			fmt.Sprintf("No cluster named %q was found. Check the name in the Typesense Cloud console, or import the cluster by ID.", name),
+		)
+		return
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusters[0].ID)...)
+	default:
+		ids := make([]string, len(clusters))
+		for i, cluster := range clusters {
+			ids[i] = cluster.ID
+		}
+		resp.Diagnostics.AddError(
+			"Ambiguous Typesense Cluster Name",
+			fmt.Sprintf("%d clusters are named %q: %s. Import the cluster by ID instead.", len(clusters), name, strings.Join(ids, ", ")),
+		)
+	}
+}
+
+// compareVersions compares two Typesense versions such as "0.24.1" or
+// "0.25.0.rc34" component by component, numerically where possible. It
+// returns -1, 0 or 1 when a is lower than, equal to or greater than b.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var ac, bc string
+		if i < len(as) {
+			ac = as[i]
+		}
+		if i < len(bs) {
+			bc = bs[i]
+		}
+		an, aErr := strconv.Atoi(ac)
+		bn, bErr := strconv.Atoi(bc)
+		// Missing numeric components count as zero, so "0.25" equals "0.25.0".
+		if ac == "" && bErr == nil {
+			an, aErr = 0, nil
+		}
+		if bc == "" && aErr == nil {
+			bn, bErr = 0, nil
+		}
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case ac == "":
+			// A release sorts after its release candidates.
+			return 1
+		case bc == "":
+			return -1
+		case ac != bc:
+			// Pre-release components such as "rc34" compare by prefix, then number.
+			ap, an := splitVersionSuffix(ac)
+			bp, bn := splitVersionSuffix(bc)
+			if ap != bp {
+				if ap < bp {
+					return -1
+				}
+				return 1
+			}
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// splitVersionSuffix splits a version component such as "rc34" into its
+// prefix and trailing number.
+func splitVersionSuffix(component string) (string, int) {
+	i := len(component)
+	for i > 0 && component[i-1] >= '0' && component[i-1] <= '9' {
+		i--
+	}
+	n, _ := strconv.Atoi(component[i:])
+	return component[:i], n
 }
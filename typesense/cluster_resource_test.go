@@ -1,18 +1,43 @@
 package typesense
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"terraform-provider-typesense/internal/fakecloud"
 	"testing"
 	"time"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
-var testClusterName = fmt.Sprintf("test-%d", time.Now().Unix())
+func TestClusterResource(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	clusterName := fmt.Sprintf("test-%d", time.Now().Unix())
+	var clusterID string
 
-func TestAccOrderResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if rs.Type != "typesense_cluster" {
+					continue
+				}
+				if cluster, _ := fake.Cluster(rs.Primary.ID); cluster.Status != fakecloud.StatusTerminated {
+					return fmt.Errorf("expected cluster %s to be terminated, got status %s", rs.Primary.ID, cluster.Status)
+				}
+			}
+			return nil
+		},
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -21,23 +46,30 @@ resource "typesense_cluster" "test" {
 	name = "%s"
 	memory = "0.5_gb"
 	vcpu = "2_vcpus_1_hr_burst_per_day"
-	region = "oregon"
+	regions = ["oregon"]
 	auto_upgrade_capacity = true
 }
-`, testClusterName),
+`, clusterName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("typesense_cluster.test", "auto_upgrade_capacity", "true"),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "high_availability", "no"),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "high_performance_disk", "no"),
-					//resource.TestCheckResourceAttr("typesense_cluster.test", "id", "05umtgeli2v8b19np"),
+					resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+						clusterID = value
+						return nil
+					}),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "load_balancing", "no"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "node_count", "1"),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "memory", "0.5_gb"),
-					resource.TestCheckResourceAttr("typesense_cluster.test", "name", testClusterName),
-					resource.TestCheckResourceAttr("typesense_cluster.test", "region", "oregon"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "name", clusterName),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "regions.0", "oregon"),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "search_delivery_network", "off"),
-					resource.TestCheckResourceAttr("typesense_cluster.test", "status", "initializing"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "status", "in_service"),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "typesense_server_version", "0.24.1"),
 					resource.TestCheckResourceAttr("typesense_cluster.test", "vcpu", "2_vcpus_1_hr_burst_per_day"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "wait_for_termination", "true"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "deletion_protection", "false"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "estimated_monthly_cost", "7.81"),
 				),
 			},
 			// ImportState testing
@@ -46,6 +78,13 @@ resource "typesense_cluster" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name testing
+			{
+				ResourceName:      "typesense_cluster.test",
+				ImportState:       true,
+				ImportStateId:     "name:" + clusterName,
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + fmt.Sprintf(`
@@ -53,16 +92,822 @@ resource "typesense_cluster" "test" {
   name = "%s_tmp"
   memory = "0.5_gb"
   vcpu = "2_vcpus_1_hr_burst_per_day"
-  region = "oregon"
+  regions = ["oregon"]
   auto_upgrade_capacity = false
 }
-`, testClusterName),
+`, clusterName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectUnknownAfterApply("typesense_cluster.test", "current_typesense_server_version", false),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("typesense_cluster.test", "auto_upgrade_capacity", "false"),
-					resource.TestCheckResourceAttr("typesense_cluster.test", "name", testClusterName+"_tmp"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "name", clusterName+"_tmp"),
+				),
+			},
+			// In-place resize testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  name = "%s_tmp"
+  memory = "1_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]
+  auto_upgrade_capacity = false
+}
+`, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "memory", "1_gb"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "vcpu", "2_vcpus_2_hr_burst_per_day"),
+					resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+						if value != clusterID {
+							return fmt.Errorf("expected cluster %s to be resized in place, got %s", clusterID, value)
+						}
+						if changes := fake.ConfigurationChanges(clusterID); len(changes) != 1 {
+							return fmt.Errorf("expected 1 configuration change, got %d", len(changes))
+						}
+						return nil
+					}),
 				),
 			},
+			// Server version upgrade testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  name = "%s_tmp"
+  memory = "1_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]
+  auto_upgrade_capacity = false
+  typesense_server_version = "0.25.0"
+}
+`, clusterName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectUnknownAfterApply("typesense_cluster.test", "current_typesense_server_version", true),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "typesense_server_version", "0.25.0"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "current_typesense_server_version", "0.25.0"),
+				),
+			},
+			// Scheduled resize is tracked as pending
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  name = "%s_tmp"
+  memory = "2_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]
+  auto_upgrade_capacity = false
+  typesense_server_version = "0.25.0"
+  perform_change_at = "2099-01-01T02:00:00Z"
+}
+`, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "memory", "2_gb"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "pending_configuration_change.status", "scheduled"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "pending_configuration_change.new_memory", "2_gb"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "pending_configuration_change.perform_change_at", "2099-01-01T02:00:00Z"),
+					resource.TestCheckNoResourceAttr("typesense_cluster.test", "pending_configuration_change.new_vcpu"),
+					func(*terraform.State) error {
+						if cluster, _ := fake.Cluster(clusterID); cluster.Memory != "1_gb" {
+							return fmt.Errorf("expected the resize to be scheduled, cluster has memory %s", cluster.Memory)
+						}
+						return nil
+					},
+				),
+			},
+			// Removing the schedule applies the pending change immediately
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  name = "%s_tmp"
+  memory = "2_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]
+  auto_upgrade_capacity = false
+  typesense_server_version = "0.25.0"
+}
+`, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "memory", "2_gb"),
+					resource.TestCheckNoResourceAttr("typesense_cluster.test", "pending_configuration_change.id"),
+					func(*terraform.State) error {
+						changes := fake.ConfigurationChanges(clusterID)
+						if got := changes[len(changes)-2].Status; got != fakecloud.ChangeCancelled {
+							return fmt.Errorf("expected the scheduled change to be cancelled, got %s", got)
+						}
+						if cluster, _ := fake.Cluster(clusterID); cluster.Memory != "2_gb" {
+							return fmt.Errorf("expected the cluster to be resized, cluster has memory %s", cluster.Memory)
+						}
+						return nil
+					},
+				),
+			},
+			// Server version downgrade is rejected at plan time
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  name = "%s_tmp"
+  memory = "1_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]
+  auto_upgrade_capacity = false
+  typesense_server_version = "0.24.1"
+}
+`, clusterName),
+				ExpectError: regexp.MustCompile("Typesense Server Downgrade Not Supported"),
+			},
+			// Out of band termination is recreated
+			{
+				PreConfig: func() {
+					fake.SetClusterStatus(clusterID, fakecloud.StatusTerminated)
+				},
+				Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  name = "%s_tmp"
+  memory = "1_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]
+  auto_upgrade_capacity = false
+  typesense_server_version = "0.25.0"
+}
+`, clusterName),
+				Check: resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+					if value == clusterID {
+						return fmt.Errorf("expected the terminated cluster %s to be replaced", clusterID)
+					}
+					return nil
+				}),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+// unknownAfterApplyCheck is a plan check asserting whether an attribute of a
+// resource is planned as known after apply.
+type unknownAfterApplyCheck struct {
+	address, attribute string
+	unknown            bool
+}
+
+func expectUnknownAfterApply(address, attribute string, unknown bool) plancheck.PlanCheck {
+	return unknownAfterApplyCheck{address: address, attribute: attribute, unknown: unknown}
+}
+
+func (c unknownAfterApplyCheck) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != c.address {
+			continue
+		}
+		afterUnknown, _ := rc.Change.AfterUnknown.(map[string]interface{})
+		if unknown, _ := afterUnknown[c.attribute].(bool); unknown != c.unknown {
+			resp.Error = fmt.Errorf("expected %s.%s to be unknown after apply: %t, got %t", c.address, c.attribute, c.unknown, unknown)
+		}
+		return
+	}
+	resp.Error = fmt.Errorf("%s is not part of the plan", c.address)
+}
+
+func TestClusterResourceOutOfBandUpgrade(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	config := providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "0.5_gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  regions = ["oregon"]
+  typesense_server_version = "0.24.1"
+}
+`
+	var clusterID string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+					clusterID = value
+					return nil
+				}),
+			},
+			// An upgrade made outside of Terraform is neither drift nor a downgrade
+			{
+				PreConfig: func() {
+					fake.SetClusterVersion(clusterID, "0.25.0")
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "typesense_server_version", "0.24.1"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "current_typesense_server_version", "0.25.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestClusterResourceSearchDeliveryNetwork(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Several regions require Search Delivery Network
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon", "frankfurt"]
+}
+`,
+				ExpectError: regexp.MustCompile("Multiple Regions Require Search Delivery Network"),
+			},
+			// Search Delivery Network cluster testing
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  high_availability = "yes"
+  search_delivery_network = "automatic"
+  regions = ["oregon", "frankfurt", "singapore"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "search_delivery_network", "automatic"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "regions.#", "3"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "regions.2", "singapore"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "node_count", "3"),
+					resource.TestCheckResourceAttrSet("typesense_cluster.test", "hostnames.nearest_node"),
+					resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+						cluster, _ := fake.Cluster(value)
+						if cluster.SearchDeliveryNetwork != "automatic" || len(cluster.Regions) != 3 {
+							return fmt.Errorf("expected an SDN cluster in 3 regions, got %q in %v", cluster.SearchDeliveryNetwork, cluster.Regions)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestClusterResourceTopology(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A highly available cluster needs an odd number of nodes
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon"]
+  high_availability = "yes"
+  node_count = 4
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Node Count"),
+			},
+			// Load balancing requires high availability
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon"]
+  load_balancing = "yes"
+}
+`,
+				ExpectError: regexp.MustCompile("Load Balancing Requires High Availability"),
+			},
+			// Search Delivery Network requires high availability
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon"]
+  search_delivery_network = "automatic"
+}
+`,
+				ExpectError: regexp.MustCompile("Search Delivery Network Requires High Availability"),
+			},
+			// Five nodes without load balancing
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon"]
+  high_availability = "yes"
+  node_count = 5
+  load_balancing = "no"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "node_count", "5"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "load_balancing", "no"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "hostnames.nodes.#", "5"),
+					resource.TestCheckResourceAttr("typesense_cluster.test", "hostnames.load_balanced", ""),
+					resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+						cluster, _ := fake.Cluster(value)
+						if cluster.NodeCount != 5 || cluster.LoadBalancing != "no" {
+							return fmt.Errorf("expected 5 nodes without load balancing, got %d nodes and load_balancing %q", cluster.NodeCount, cluster.LoadBalancing)
+						}
+						return nil
+					}),
+				),
+			},
+			// Enabling load balancing replaces the cluster
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon"]
+  high_availability = "yes"
+  node_count = 5
+  load_balancing = "yes"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_cluster.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "load_balancing", "yes"),
+					resource.TestCheckResourceAttrSet("typesense_cluster.test", "hostnames.load_balanced"),
+				),
+			},
+			// Changing the node count replaces the cluster
+			{
+				Config: providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  regions = ["oregon"]
+  high_availability = "yes"
+  node_count = 3
+  load_balancing = "yes"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("typesense_cluster.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("typesense_cluster.test", "node_count", "3"),
+			},
+		},
+	})
+}
+
+func TestClusterResourceDeletionProtection(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	config := func(region string, protected bool) string {
+		return providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  memory = "0.5_gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  regions = [%q]
+  deletion_protection = %t
+}
+`, region, protected)
+	}
+	inService := resource.TestCheckResourceAttrWith("typesense_cluster.test", "id", func(value string) error {
+		if cluster, _ := fake.Cluster(value); cluster.Status != fakecloud.StatusInService {
+			return fmt.Errorf("expected protected cluster %s to stay in service, got status %s", value, cluster.Status)
+		}
+		return nil
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if cluster, _ := fake.Cluster(rs.Primary.ID); cluster.Status != fakecloud.StatusTerminated {
+					return fmt.Errorf("expected cluster %s to be terminated, got status %s", rs.Primary.ID, cluster.Status)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("oregon", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "deletion_protection", "true"),
+					inService,
+				),
+			},
+			// Replacement is refused
+			{
+				Config:      config("frankfurt", true),
+				ExpectError: regexp.MustCompile("Typesense Cluster Deletion Protected"),
+			},
+			// Destroy is refused
+			{
+				Config:      config("oregon", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("set deletion_protection = false"),
+			},
+			// Turning the protection off allows the destroy of the TestCase
+			{
+				Config: config("oregon", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test", "deletion_protection", "false"),
+					inService,
+				),
+			},
+		},
+	})
+}
+
+func TestClusterResourceImportByName(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	for i := 0; i < 2; i++ {
+		fake.AddCluster(fakecloud.Cluster{Name: "twin", Memory: "0.5_gb", VCPU: "2_vcpus_1_hr_burst_per_day", Regions: []string{"oregon"}})
+	}
+	fake.AddCluster(fakecloud.Cluster{Name: "reused", Status: fakecloud.StatusTerminated, Regions: []string{"oregon"}})
+	config := providerConfig + `
+resource "typesense_cluster" "test" {
+  memory = "0.5_gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  regions = ["oregon"]
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        config,
+				ResourceName:  "typesense_cluster.test",
+				ImportState:   true,
+				ImportStateId: "name:missing",
+				ExpectError:   regexp.MustCompile(`No cluster named "missing" was found`),
+			},
+			// Terminated clusters don't count
+			{
+				Config:        config,
+				ResourceName:  "typesense_cluster.test",
+				ImportState:   true,
+				ImportStateId: "name:reused",
+				ExpectError:   regexp.MustCompile(`No cluster named "reused" was found`),
+			},
+			{
+				Config:        config,
+				ResourceName:  "typesense_cluster.test",
+				ImportState:   true,
+				ImportStateId: "name:twin",
+				ExpectError:   regexp.MustCompile(`2 clusters are named "twin"`),
+			},
+		},
+	})
+}
+
+func TestClusterResourceMaxMonthlyCost(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t, "max_monthly_cost = 20")
+	// Terminated clusters don't count
+	fake.AddCluster(fakecloud.Cluster{Memory: "1_gb", VCPU: "2_vcpus", Regions: []string{"oregon"}, Status: fakecloud.StatusTerminated})
+	config := func(count int, memory, vcpu string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  count = %d
+  memory = %q
+  vcpu = %q
+  regions = ["oregon"]
+}
+`, count, memory, vcpu)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(2, "0.5_gb", "2_vcpus_1_hr_burst_per_day"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("typesense_cluster.test.0", "estimated_monthly_cost", "7.81"),
+					resource.TestCheckResourceAttr("typesense_cluster.test.1", "estimated_monthly_cost", "7.81"),
+				),
+			},
+			// The budget applies to the sum of the clusters of the account
+			{
+				Config:      config(3, "0.5_gb", "2_vcpus_1_hr_burst_per_day"),
+				ExpectError: regexp.MustCompile(`Monthly Cost Budget Exceeded(.|\n)*23.43 USD per month`),
+			},
+			// Growing a cluster replaces its own cost
+			{
+				Config:      config(2, "1_gb", "2_vcpus"),
+				ExpectError: regexp.MustCompile(`Monthly Cost Budget Exceeded(.|\n)*48.33 USD per month`),
+			},
+			// Clusters outside the configuration count too
+			{
+				PreConfig: func() {
+					fake.AddCluster(fakecloud.Cluster{Memory: "0.5_gb", VCPU: "2_vcpus_1_hr_burst_per_day", Regions: []string{"oregon"}})
+				},
+				Config:      config(2, "0.5_gb", "2_vcpus_1_hr_burst_per_day"),
+				ExpectError: regexp.MustCompile(`Monthly Cost Budget Exceeded(.|\n)*23.43 USD per month`),
+			},
+		},
+	})
+}
+
+func TestClusterResourceCatalogValidation(t *testing.T) {
+	_, providerConfig := newFakeCloud(t)
+
+	tests := map[string]struct {
+		config string
+		error  string
+	}{
+		"memory typo": {
+			config: `
+  memory = "0.5gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  regions = ["oregon"]`,
+			error: `Invalid Memory(.|\n)*Did you mean "0.5_gb"\?`,
+		},
+		"region typo": {
+			config: `
+  memory = "0.5_gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  regions = ["oregn"]`,
+			error: `Invalid Region(.|\n)*Did you mean "oregon"\?`,
+		},
+		"burst vcpu with large memory": {
+			config: `
+  memory = "64_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  regions = ["oregon"]`,
+			error: `Incompatible vCPU(.|\n)*not available with memory "64_gb"`,
+		},
+		"high performance disk with burst vcpu": {
+			config: `
+  memory = "1_gb"
+  vcpu = "2_vcpus_2_hr_burst_per_day"
+  high_performance_disk = "yes"
+  regions = ["oregon"]`,
+			error: "High Performance Disk Not Available",
+		},
+		"high availability with smallest burst vcpu": {
+			config: `
+  memory = "0.5_gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  high_availability = "yes"
+  regions = ["oregon"]`,
+			error: "High Availability Not Available",
+		},
+		"search delivery network typo": {
+			config: `
+  memory = "2_gb"
+  vcpu = "2_vcpus"
+  high_availability = "yes"
+  search_delivery_network = "automatc"
+  regions = ["oregon", "frankfurt"]`,
+			error: `Invalid Value(.|\n)*search_delivery_network`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      providerConfig + "resource \"typesense_cluster\" \"test\" {" + tt.config + "\n}\n",
+						ExpectError: regexp.MustCompile(tt.error),
+					},
+				},
+			})
+		})
+	}
+}
+
+// clusterStateV0 is a typesense_cluster state written by the provider before
+// clusters could span several regions.
+const clusterStateV0 = `{
+  "id": "abc123",
+  "name": "search",
+  "memory": "0.5_gb",
+  "vcpu": "2_vcpus_1_hr_burst_per_day",
+  "high_performance_disk": "no",
+  "typesense_server_version": "0.24.1",
+  "high_availability": "no",
+  "search_delivery_network": "off",
+  "load_balancing": "no",
+  "region": "oregon",
+  "auto_upgrade_capacity": false,
+  "status": "in_service",
+  "hostnames": {
+    "load_balanced": "",
+    "nodes": ["abc123-1.a1.typesense.net"]
+  }
+}`
+
+func TestClusterResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	// The prior schema must describe exactly the attributes of version 0.
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(clusterStateV0), &raw); err != nil {
+		t.Fatal(err)
+	}
+	prior := clusterResourceSchemaV0()
+	if len(prior.Attributes) != len(raw) {
+		t.Errorf("expected %d attributes in the version 0 schema, got %d", len(raw), len(prior.Attributes))
+	}
+	for name := range raw {
+		if _, ok := prior.Attributes[name]; !ok {
+			t.Errorf("version 0 schema lacks attribute %s", name)
+		}
+	}
+	if hostnames := prior.Attributes["hostnames"].(schema.SingleNestedAttribute); len(hostnames.Attributes) != 2 {
+		t.Errorf("expected 2 hostnames attributes in the version 0 schema, got %d", len(hostnames.Attributes))
+	}
+
+	server, err := testAccProtoV6ProviderFactories["typesense"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The provider only knows its resource types once Terraform asked for its schema.
+	if _, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "typesense_cluster",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(clusterStateV0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	upgraded, err := resp.UpgradedState.Unmarshal(clusterResourceSchema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var state typesenseClusterModel
+	diags := tfsdk.State{Schema: clusterResourceSchema, Raw: upgraded}.Get(ctx, &state)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var regions, nodes []string
+	diags.Append(state.Regions.ElementsAs(ctx, &regions, false)...)
+	diags.Append(state.Hostnames.Attributes()["nodes"].(types.List).ElementsAs(ctx, &nodes, false)...)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if state.ID.ValueString() != "abc123" || len(regions) != 1 || regions[0] != "oregon" {
+		t.Errorf("expected cluster abc123 in [oregon], got %s in %v", state.ID, regions)
+	}
+	if state.Memory.ValueString() != "0.5_gb" || state.Status.ValueString() != "in_service" {
+		t.Errorf("expected the version 0 attributes to be kept, got memory %s and status %s", state.Memory, state.Status)
+	}
+	if len(nodes) != 1 || !state.Hostnames.Attributes()["nearest_node"].IsNull() {
+		t.Errorf("expected the node hostnames to be kept and the nearest node to be null, got %s", state.Hostnames)
+	}
+	if !state.DeletionProtection.IsNull() || !state.Timeouts.IsNull() {
+		t.Errorf("expected attributes added since version 0 to be null, got deletion_protection %s and timeouts %s", state.DeletionProtection, state.Timeouts)
+	}
+}
+
+func TestClusterResourceProvisioningFailure(t *testing.T) {
+	for name, tc := range map[string]struct {
+		setup   func(*fakecloud.Server)
+		timeout string
+		wait    bool
+		err     string
+	}{
+		"failed": {
+			setup:   func(fake *fakecloud.Server) { fake.ProvisionedStatus = fakecloud.StatusFailed },
+			timeout: "1m",
+			wait:    true,
+			err:     `reached terminal status "failed"`,
+		},
+		"timeout": {
+			// The cluster never settles, so its termination isn't waited for either.
+			setup:   func(fake *fakecloud.Server) { fake.TransitionPolls = 1000 },
+			timeout: "1s",
+			wait:    false,
+			err:     `timed out waiting for status in_service, last status was\s+"initializing"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake, providerConfig := newFakeCloud(t)
+			tc.setup(fake)
+
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + fmt.Sprintf(`
+resource "typesense_cluster" "test" {
+  memory = "0.5_gb"
+  vcpu = "2_vcpus_1_hr_burst_per_day"
+  regions = ["oregon"]
+  wait_for_termination = %t
+
+  timeouts {
+    create = %q
+  }
+}
+`, tc.wait, tc.timeout),
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestClusterResourceConfigure(t *testing.T) {
+	cr := &clusterResource{}
+	resp := &fwresource.ConfigureResponse{}
+	cr.Configure(context.Background(), fwresource.ConfigureRequest{ProviderData: "not a client"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected unexpected provider data to be reported")
+	}
+
+	_, client := newFakeCloudClient(t)
+	poll := pollConfig{Interval: time.Millisecond, MaxInterval: time.Second}
+	resp = &fwresource.ConfigureResponse{}
+	cr.Configure(context.Background(), fwresource.ConfigureRequest{ProviderData: &providerData{client: client, poll: poll}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if cr.client != client {
+		t.Error("expected the provider client to be used")
+	}
+	if cr.poll != poll {
+		t.Errorf("expected the provider poll configuration to be used, got %+v", cr.poll)
+	}
+}
+
+func TestClusterResourceReadRemovesGoneClusters(t *testing.T) {
+	fake, client := newFakeCloudClient(t)
+	fake.AddCluster(fakecloud.Cluster{ID: "terminated", Status: fakecloud.StatusTerminated, Regions: []string{"oregon"}})
+	cr := &clusterResource{client: client}
+
+	for _, id := range []string{"missing", "terminated"} {
+		state := newTestState(t, clusterResourceSchema, typesenseClusterModel{
+			ID:                         types.StringValue(id),
+			Regions:                    types.ListNull(types.StringType),
+			Hostnames:                  types.ObjectNull(hostnamesAttrTypes),
+			PendingConfigurationChange: types.ObjectNull(pendingChangeAttrTypes),
+			Timeouts:                   types.ObjectNull(timeoutsAttrTypes(clusterTimeouts)),
+		})
+		resp := &fwresource.ReadResponse{State: state}
+		cr.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", id, resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Errorf("%s: expected the cluster to be removed from state", id)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"0.24.1", "0.24.1", 0},
+		{"0.24.1", "0.25.0", -1},
+		{"0.25.0", "0.24.1", 1},
+		{"0.25.10", "0.25.9", 1},
+		{"0.25.0.rc34", "0.25.0", -1},
+		{"0.25.0", "0.25.0.rc34", 1},
+		{"0.25.0.rc9", "0.25.0.rc34", -1},
+		{"0.25.0.rc34", "0.25.0.rc34", 0},
+		{"0.25", "0.25.0", 0},
+		{"0.24", "0.24.1", -1},
+	} {
+		if got := compareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &clustersDataSource{}
+
+	clustersDataSourceSchema = schema.Schema{
+		Description: "Lists the clusters of the account, optionally filtered. Every filter that is set must match.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier of the listing.",
+				Computed:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Regular expression, in RE2 syntax, the cluster names must match.",
+				Optional:    true,
+				Validators: []validator.String{
+					regexpValidator{},
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status the clusters must be in, such as in_service.",
+				Optional:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "Region the clusters must have nodes in.",
+				Optional:    true,
+				Validators: []validator.String{
+					catalogValidator{kind: "Region", values: catalog.regionValues()},
+				},
+			},
+			"memory": schema.StringAttribute{
+				Description: "How much RAM the clusters must have.",
+				Optional:    true,
+				Validators: []validator.String{
+					catalogValidator{kind: "Memory", values: catalog.memoryValues()},
+				},
+			},
+			"typesense_server_version": schema.StringAttribute{
+				Description: "Typesense server version the clusters must run.",
+				Optional:    true,
+			},
+			"include_pending_configuration_changes": schema.BoolAttribute{
+				Description: "When set to true, the pending configuration change of every listed cluster is read, which takes one more request per cluster. " +
+					"Defaults to false, leaving pending_configuration_change null.",
+				Optional: true,
+			},
+			"clusters": schema.ListNestedAttribute{
+				Description: "Clusters matching the filters, with the same attributes as the typesense_cluster data source.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: listedClusterAttributes(),
+				},
+			},
+		},
+	}
+)
+
+// listedClusterAttributes returns the attributes of the typesense_cluster
+// data source, all computed, for the clusters of a listing.
+func listedClusterAttributes() map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(clusterDataSourceSchema.Attributes))
+	for name, attribute := range clusterDataSourceSchema.Attributes {
+		attributes[name] = attribute
+	}
+	attributes["id"] = schema.StringAttribute{
+		Description: "Autogenerated ID assigned by the Typesense engine.",
+		Computed:    true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "A string to identify the cluster for your reference in the Typesense Cloud Web console.",
+		Computed:    true,
+	}
+	return attributes
+}
+
+func NewClustersDataSource() datasource.DataSource {
+	return &clustersDataSource{}
+}
+
+type clustersDataSource struct {
+	client CloudClient
+}
+
+func (cds *clustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clusters"
+}
+
+func (cds *clustersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = clustersDataSourceSchema
+}
+
+func (cds *clustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config typesenseClustersDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := cds.client.ListClusters(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list Typesense clusters",
+			describeError(err),
+		)
+		return
+	}
+
+	// The expression was validated at plan time.
+	nameRegex, _ := regexp.Compile(config.NameRegex.ValueString())
+	config.ID = types.StringValue("clusters")
+	config.Clusters = []typesenseClusterDataSourceModel{}
+	for i := range clusters {
+		cluster := &clusters[i]
+		if !config.matches(cluster, nameRegex) {
+			continue
+		}
+		var pending *typesenseConfigurationChange
+		// Terminated clusters have no configuration changes left to apply.
+		if config.IncludePendingConfigurationChanges.ValueBool() && !cluster.isTerminated() {
+			var err error
+			pending, err = pendingConfigurationChange(ctx, cds.client, cluster.ID)
+			if err != nil && !IsNotFound(err) {
+				resp.Diagnostics.AddError(
+					"Unable to read Typesense cluster configuration changes",
+					"Could not read configuration changes of Typesense Cluster ID "+cluster.ID+": "+describeError(err),
+				)
+				return
+			}
+		}
+		var tcm typesenseClusterDataSourceModel
+		tcm.refresh(cluster, pending)
+		config.Clusters = append(config.Clusters, tcm)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// matches reports whether the cluster passes every filter set in the
+// configuration.
+func (m *typesenseClustersDataSourceModel) matches(cluster *typesenseCluster, nameRegex *regexp.Regexp) bool {
+	if !m.NameRegex.IsNull() && !nameRegex.MatchString(cluster.Name) {
+		return false
+	}
+	if !m.Status.IsNull() && cluster.Status != m.Status.ValueString() {
+		return false
+	}
+	if !m.Memory.IsNull() && cluster.Memory != m.Memory.ValueString() {
+		return false
+	}
+	if !m.TypesenseServerVersion.IsNull() && cluster.TypesenseServerVersion != m.TypesenseServerVersion.ValueString() {
+		return false
+	}
+	if !m.Region.IsNull() {
+		for _, region := range cluster.Regions {
+			if region == m.Region.ValueString() {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Configure adds the provider configured client to the data source.
+func (cds *clustersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *typesense.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	cds.client = data.client
+}
@@ -0,0 +1,105 @@
+package typesense
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"terraform-provider-typesense/internal/fakecloud"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestClustersDataSource(t *testing.T) {
+	fake, providerConfig := newFakeCloud(t)
+	fake.MaxPerPage = 2
+	for _, cluster := range []fakecloud.Cluster{
+		{ID: "a0000000000000001", Name: "search-production", Memory: "4_gb", VCPU: "2_vcpus", TypesenseServerVersion: "0.25.0", HighAvailability: "yes", Regions: []string{"oregon"}},
+		{ID: "a0000000000000002", Name: "search-staging", Memory: "0.5_gb", VCPU: "2_vcpus_1_hr_burst_per_day", TypesenseServerVersion: "0.24.1", Regions: []string{"frankfurt"}},
+		{ID: "a0000000000000003", Name: "search-legacy", Memory: "0.5_gb", VCPU: "2_vcpus_1_hr_burst_per_day", TypesenseServerVersion: "0.24.1", Regions: []string{"oregon"}, Status: fakecloud.StatusTerminated},
+		{ID: "a0000000000000004", Name: "analytics", Memory: "4_gb", VCPU: "2_vcpus", TypesenseServerVersion: "0.25.0", SearchDeliveryNetwork: "automatic", Regions: []string{"oregon", "frankfurt"}},
+	} {
+		cluster.Hostnames.Nodes = []string{cluster.ID + "-1.a1.typesense.net"}
+		fake.AddCluster(cluster)
+	}
+	client, err := NewClient(clientConfig{Key: testKey, Endpoint: fake.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateConfigurationChange(context.Background(), typesenseConfigurationChange{
+		ClusterID:       "a0000000000000001",
+		NewMemory:       "8_gb",
+		PerformChangeAt: time.Date(2099, 1, 1, 2, 0, 0, 0, time.UTC).Unix(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Configuration changes of a cluster being deleted may not be found, and
+	// those of terminated clusters must not be requested at all.
+	fake.InjectFault(fakecloud.Fault{Status: http.StatusNotFound, Method: http.MethodGet, PathPrefix: "/clusters/a0000000000000002/configuration-changes"}, 1000)
+	fake.InjectFault(fakecloud.Fault{Status: http.StatusBadRequest, PathPrefix: "/clusters/a0000000000000003/configuration-changes"}, 1000)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "typesense_clusters" "test" {
+  name_regex = "search-("
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Regular Expression"),
+			},
+			{
+				Config: providerConfig + `
+data "typesense_clusters" "all" {}
+
+data "typesense_clusters" "search" {
+  name_regex = "^search-"
+  status = "in_service"
+}
+
+data "typesense_clusters" "frankfurt" {
+  region = "frankfurt"
+  memory = "4_gb"
+  typesense_server_version = "0.25.0"
+}
+
+data "typesense_clusters" "none" {
+  name_regex = "^nothing$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_clusters.all", "clusters.#", "4"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.search", "clusters.#", "2"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.search", "clusters.0.name", "search-production"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.search", "clusters.0.high_availability", "yes"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.search", "clusters.1.name", "search-staging"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.search", "clusters.1.vcpu", "2_vcpus_1_hr_burst_per_day"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.search", "clusters.1.hostnames.nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.frankfurt", "clusters.#", "1"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.frankfurt", "clusters.0.id", "a0000000000000004"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.frankfurt", "clusters.0.regions.#", "2"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.frankfurt", "clusters.0.search_delivery_network", "automatic"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.none", "clusters.#", "0"),
+					resource.TestCheckNoResourceAttr("data.typesense_clusters.all", "clusters.0.pending_configuration_change.id"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "typesense_clusters" "pending" {
+  include_pending_configuration_changes = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.typesense_clusters.pending", "clusters.#", "4"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.pending", "clusters.0.pending_configuration_change.status", "scheduled"),
+					resource.TestCheckResourceAttr("data.typesense_clusters.pending", "clusters.0.pending_configuration_change.new_memory", "8_gb"),
+					resource.TestCheckNoResourceAttr("data.typesense_clusters.pending", "clusters.1.pending_configuration_change.id"),
+					resource.TestCheckNoResourceAttr("data.typesense_clusters.pending", "clusters.2.pending_configuration_change.id"),
+				),
+			},
+		},
+	})
+}
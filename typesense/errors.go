@@ -0,0 +1,130 @@
+package typesense
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// maxErrorBodyLength caps how much of a response body is kept on an APIError.
+const maxErrorBodyLength = 1024
+
+// secretFieldPattern matches JSON string fields that may carry credentials.
+var secretFieldPattern = regexp.MustCompile(`"(admin_key|search_only_key|api_key|key|value)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// APIError is returned when the Cloud Management API answers with an error.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message reported by the API, if any.
+	Message string
+	// RequestID identifies the request in Typesense Cloud logs, if the API sent one.
+	RequestID string
+	// Retryable reports whether the same request may succeed when sent again.
+	Retryable bool
+	// Body is the response body with credentials redacted.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	if e.StatusCode >= 200 && e.StatusCode <= 299 {
+		sb.WriteString("Typesense Cloud API reported an unsuccessful request")
+	} else {
+		fmt.Fprintf(&sb, "Typesense Cloud API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Message != "" {
+		sb.WriteString(": " + e.Message)
+	} else if e.Body != "" {
+		sb.WriteString(": " + e.Body)
+	}
+	if e.RequestID != "" {
+		sb.WriteString(" (request ID " + e.RequestID + ")")
+	}
+	return sb.String()
+}
+
+// newAPIError builds an APIError from a response status, headers and body.
+func newAPIError(status int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: status,
+		Message:    errorMessage(body),
+		Retryable:  isRetryableStatus(status),
+		Body:       redactBody(body),
+	}
+	if header != nil {
+		apiErr.RequestID = header.Get("X-Request-Id")
+	}
+	return apiErr
+}
+
+// errorMessage extracts the message of an API error payload.
+func errorMessage(body []byte) string {
+	var payload struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	if payload.Message != "" {
+		return payload.Message
+	}
+	return payload.Error
+}
+
+// redactBody masks credential values in a response body and truncates it so it
+// is safe to include in errors and logs.
+func redactBody(body []byte) string {
+	redacted := secretFieldPattern.ReplaceAllString(string(body), `"$1"$2"<redacted>"`)
+	if len(redacted) > maxErrorBodyLength {
+		redacted = redacted[:maxErrorBodyLength] + "..."
+	}
+	return strings.TrimSpace(redacted)
+}
+
+// decodeResponse unmarshals a successful response body into v.
+func decodeResponse(body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unable to decode Typesense Cloud API response %q: %w", redactBody(body), err)
+	}
+	return nil
+}
+
+// IsNotFound reports whether err is an APIError for a missing object.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError for a request that conflicts
+// with the current state of the object.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is an APIError for a throttled request.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// describeError renders err for a diagnostic detail, adding guidance for the
+// API errors practitioners can act upon.
+func describeError(err error) string {
+	switch {
+	case IsNotFound(err):
+		return err.Error() + "\n\nThe object does not exist or is not visible to this Cloud Management API key."
+	case IsConflict(err):
+		return err.Error() + "\n\nThe cluster is not in a state that allows this operation, for example because another change is still in progress. Wait for it to finish and try again."
+	case IsRateLimited(err):
+		return err.Error() + "\n\nTypesense Cloud kept rate limiting the request after all retries. Lower the Terraform parallelism or raise max_retries."
+	}
+	return err.Error()
+}
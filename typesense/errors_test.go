@@ -0,0 +1,65 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorClassification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		switch r.URL.Path {
+		case "/clusters/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<html>not found</html>`))
+		case "/clusters/busy/lifecycle":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"success":false,"message":"Cluster is busy"}`))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(clientConfig{Key: "test", Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.maxRetries = 0
+
+	_, err = client.GetCluster(context.Background(), "missing")
+	if !IsNotFound(err) || IsConflict(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "req-123") {
+		t.Errorf("expected request ID in %q", err)
+	}
+
+	err = client.TerminateCluster(context.Background(), "busy")
+	if !IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Cluster is busy") {
+		t.Errorf("expected API message in %q", err)
+	}
+
+	_, err = client.GetCluster(context.Background(), "throttled")
+	if !IsRateLimited(fmt.Errorf("wrapped: %w", err)) {
+		t.Errorf("expected a rate limited error, got %v", err)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"success":true,"api_keys":{"admin_key":"s3cr3t","search_only_key" : "an\"other"},"id":"abc"}`
+	redacted := redactBody([]byte(body))
+	if strings.Contains(redacted, "s3cr3t") || strings.Contains(redacted, "other") {
+		t.Errorf("expected keys to be redacted, got %s", redacted)
+	}
+	if !strings.Contains(redacted, `"id":"abc"`) {
+		t.Errorf("expected other fields to be kept, got %s", redacted)
+	}
+}
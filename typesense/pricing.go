@@ -0,0 +1,79 @@
+package typesense
+
+import (
+	_ "embed"
+	"encoding/json"
+	"math"
+)
+
+// pricingJSON holds the hourly prices of Typesense Cloud clusters used to
+// estimate their monthly cost. It mirrors https://cloud.typesense.org/pricing
+// and must be updated when prices change.
+//
+//go:embed pricing.json
+var pricingJSON []byte
+
+// pricingTable holds hourly prices, in Currency.
+type pricingTable struct {
+	Currency      string  `json:"currency"`
+	HoursPerMonth float64 `json:"hours_per_month"`
+	// NodeHourly is the price of a node by memory then vcpu.
+	NodeHourly map[string]map[string]float64 `json:"node_hourly"`
+	// HighPerformanceDiskHourly is the additional price of a node with a
+	// high performance disk, by memory.
+	HighPerformanceDiskHourly map[string]float64 `json:"high_performance_disk_hourly"`
+	// SearchDeliveryNetworkRegionHourly is the additional price of each
+	// region of a cluster spanning several regions.
+	SearchDeliveryNetworkRegionHourly float64 `json:"search_delivery_network_region_hourly"`
+}
+
+// pricing is the embedded pricing table, loaded once.
+var pricing = mustParsePricing(pricingJSON)
+
+func mustParsePricing(data []byte) *pricingTable {
+	var p pricingTable
+	if err := json.Unmarshal(data, &p); err != nil {
+		panic("invalid embedded pricing table: " + err.Error())
+	}
+	return &p
+}
+
+// clusterShape is what the price of a cluster depends on.
+type clusterShape struct {
+	Memory              string
+	VCPU                string
+	HighPerformanceDisk bool
+	Nodes               int64
+	Regions             int
+}
+
+// monthlyCost estimates the monthly cost of a cluster, rounded to the cent.
+// It reports false when the table has no price for the memory and vcpu.
+func (p *pricingTable) monthlyCost(shape clusterShape) (float64, bool) {
+	node, ok := p.NodeHourly[shape.Memory][shape.VCPU]
+	if !ok {
+		return 0, false
+	}
+	if shape.HighPerformanceDisk {
+		node += p.HighPerformanceDiskHourly[shape.Memory]
+	}
+	hourly := node * float64(shape.Nodes)
+	if shape.Regions > 1 {
+		hourly += p.SearchDeliveryNetworkRegionHourly * float64(shape.Regions)
+	}
+	return math.Round(hourly*p.HoursPerMonth*100) / 100, true
+}
+
+// defaultNodeCount is the number of nodes of a cluster that doesn't set node_count.
+func defaultNodeCount(highAvailability string) int64 {
+	if highAvailability == "yes" {
+		return 3
+	}
+	return 1
+}
+
+// exceedsBudget reports whether cost is over max, compared to the cent. There
+// is no limit when max is zero.
+func exceedsBudget(cost, max float64) bool {
+	return max > 0 && math.Round(cost*100) > math.Round(max*100)
+}
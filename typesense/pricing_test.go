@@ -0,0 +1,67 @@
+package typesense
+
+import (
+	"testing"
+)
+
+func TestPricingCoversCatalog(t *testing.T) {
+	for _, tier := range catalog.Memory {
+		for _, vcpu := range tier.VCPU {
+			if _, ok := pricing.monthlyCost(clusterShape{Memory: tier.Value, VCPU: vcpu, Nodes: 1, Regions: 1}); !ok {
+				t.Errorf("no price for memory %q with vcpu %q", tier.Value, vcpu)
+			}
+		}
+		if _, ok := pricing.HighPerformanceDiskHourly[tier.Value]; !ok {
+			t.Errorf("no high performance disk price for memory %q", tier.Value)
+		}
+	}
+}
+
+func TestPricingMonthlyCost(t *testing.T) {
+	table := &pricingTable{
+		HoursPerMonth:                     100,
+		NodeHourly:                        map[string]map[string]float64{"1_gb": {"2_vcpus": 0.1}},
+		HighPerformanceDiskHourly:         map[string]float64{"1_gb": 0.05},
+		SearchDeliveryNetworkRegionHourly: 0.02,
+	}
+	tests := []struct {
+		name  string
+		shape clusterShape
+		want  float64
+		ok    bool
+	}{
+		{name: "single node", shape: clusterShape{Memory: "1_gb", VCPU: "2_vcpus", Nodes: 1, Regions: 1}, want: 10, ok: true},
+		{name: "high availability", shape: clusterShape{Memory: "1_gb", VCPU: "2_vcpus", Nodes: 3, Regions: 1}, want: 30, ok: true},
+		{name: "high performance disk", shape: clusterShape{Memory: "1_gb", VCPU: "2_vcpus", HighPerformanceDisk: true, Nodes: 3, Regions: 1}, want: 45, ok: true},
+		{name: "search delivery network", shape: clusterShape{Memory: "1_gb", VCPU: "2_vcpus", Nodes: 3, Regions: 3}, want: 36, ok: true},
+		{name: "unknown", shape: clusterShape{Memory: "2_gb", VCPU: "2_vcpus", Nodes: 1, Regions: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := table.monthlyCost(tt.shape)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("monthlyCost() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestExceedsBudget(t *testing.T) {
+	tests := []struct {
+		cost, max float64
+		want      bool
+	}{
+		{cost: 7.81, max: 20, want: false},
+		{cost: 20, max: 20, want: false},
+		{cost: 20.01, max: 20, want: true},
+		// Costs are compared to the cent.
+		{cost: 20.004, max: 20, want: false},
+		// No limit without a budget.
+		{cost: 1e6, max: 0, want: false},
+	}
+	for _, tt := range tests {
+		if got := exceedsBudget(tt.cost, tt.max); got != tt.want {
+			t.Errorf("exceedsBudget(%v, %v) = %v, want %v", tt.cost, tt.max, got, tt.want)
+		}
+	}
+}
@@ -2,9 +2,13 @@ package typesense
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -15,7 +19,8 @@ import (
 )
 
 const (
-	keyEnvName = "TYPESENSE_MANAGEMENT_KEY"
+	keyEnvName      = "TYPESENSE_MANAGEMENT_KEY"
+	endpointEnvName = "TYPESENSE_MANAGEMENT_ENDPOINT"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -24,16 +29,34 @@ var (
 )
 
 // New is a helper function to simplify provider server and testing implementation.
-func New() provider.Provider {
-	return &typesenseProvider{}
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &typesenseProvider{
+			version: version,
+		}
+	}
 }
 
 // typesenseProvider is the provider implementation.
-type typesenseProvider struct{}
+type typesenseProvider struct {
+	// version is set to the provider version on release, "dev" when the
+	// provider is built and ran locally, and "test" when running acceptance
+	// testing.
+	version string
+}
+
+// providerData is handed by the provider to resources and data sources.
+type providerData struct {
+	client CloudClient
+	poll   pollConfig
+	// maxMonthlyCost is the budget of each cluster, no limit when zero.
+	maxMonthlyCost float64
+}
 
 // Metadata returns the provider type name.
 func (p *typesenseProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "typesense"
+	resp.Version = p.version
 }
 
 // Schema defines the provider-level schema for configuration data.
@@ -46,6 +69,63 @@ func (p *typesenseProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"endpoint": schema.StringAttribute{
+				Description: "Base URL of the Cloud Management API. Useful to target a staging API or a local stand-in server. Can also be set with the " + endpointEnvName + " environment variable. Defaults to `" + defaultEndpoint + "`.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of times a failed Cloud Management API request is retried. Rate limited (429) responses and refused connections are always retried. Server side (5xx) responses and dropped connections are only retried for reads and deletions, since the API may already have created a cluster, configuration change or API key. Defaults to %d.", defaultMaxRetries),
+				Optional:    true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: fmt.Sprintf("Maximum number of Cloud Management API requests per second, shared by all resources and data sources of this provider. Set to 0 to disable the limit. Defaults to %d.", defaultMaxRequestsPerSecond),
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of Cloud Management API requests in flight at once, shared by all resources and data sources of this provider. Set to 0 to disable the limit. Defaults to %d.", defaultMaxConcurrentRequests),
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy requests are sent through, such as `http://proxy.example.com:3128`. Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.",
+				Optional:    true,
+			},
+			"ca_bundle": schema.StringAttribute{
+				Description: "Path to a file containing, or the content of, PEM encoded CA certificates trusted in addition to the system roots. Needed behind TLS intercepting proxies.",
+				Optional:    true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "Path to a file containing, or the content of, a PEM encoded client certificate presented for mutual TLS. Requires `client_key`.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "Path to a file containing, or the content of, the PEM encoded private key of `client_certificate`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum duration of a single HTTP request, as a duration string such as `30s`. Each retry gets its own timeout. Defaults to `" + defaultRequestTimeout.String() + "`.",
+				Optional:    true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Description: fmt.Sprintf("Minimum time to wait before retrying a failed request, as a duration string such as `500ms` or `2s`. Defaults to `%s`.", defaultRetryWaitMin),
+				Optional:    true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Description: fmt.Sprintf("Maximum time to wait before retrying a failed request, as a duration string. Also caps the delay requested by a `Retry-After` header. Defaults to `%s`.", defaultRetryWaitMax),
+				Optional:    true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: fmt.Sprintf("Time to wait before checking again on a cluster being provisioned, resized or terminated, as a duration string. The delay grows by %g times after each check, up to `max_poll_interval`. Defaults to `%s`.", pollBackoffFactor, defaultPollInterval),
+				Optional:    true,
+			},
+			"max_poll_interval": schema.StringAttribute{
+				Description: fmt.Sprintf("Maximum time to wait between two checks on a cluster being provisioned, resized or terminated, as a duration string. Defaults to `%s`.", defaultMaxPollInterval),
+				Optional:    true,
+			},
+			"max_monthly_cost": schema.Float64Attribute{
+				Description: fmt.Sprintf("Budget, in %s, for the estimated monthly cost of the account's clusters. Plans fail when the estimated_monthly_cost of a typesense_cluster resource, added to the estimates of the other clusters of the account that aren't terminated, exceeds it. Clusters created in the same plan don't exist yet and aren't counted against each other. No limit when unset.", pricing.Currency),
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -61,6 +141,25 @@ func (p *typesenseProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
+	poll := pollConfig{
+		Interval:    parseDurationAttribute(config.PollInterval, path.Root("poll_interval"), defaultPollInterval, &resp.Diagnostics),
+		MaxInterval: parseDurationAttribute(config.MaxPollInterval, path.Root("max_poll_interval"), defaultMaxPollInterval, &resp.Diagnostics),
+	}
+	var maxMonthlyCost float64
+	if !config.MaxMonthlyCost.IsNull() && !config.MaxMonthlyCost.IsUnknown() {
+		maxMonthlyCost = config.MaxMonthlyCost.ValueFloat64()
+		if maxMonthlyCost <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_monthly_cost"),
+				"Invalid Max Monthly Cost",
+				fmt.Sprintf("Expected max_monthly_cost to be greater than zero, got %g.", maxMonthlyCost),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 	if config.Key.IsUnknown() {
@@ -71,6 +170,14 @@ func (p *typesenseProvider) Configure(ctx context.Context, req provider.Configur
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the "+keyEnvName+" environment variable.",
 		)
 	}
+	if config.Endpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Unknown Typesense API Endpoint",
+			"The provider cannot create the Typesense API client as there is an unknown configuration value for the Cloud Management API endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the "+endpointEnvName+" environment variable.",
+		)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -81,6 +188,10 @@ func (p *typesenseProvider) Configure(ctx context.Context, req provider.Configur
 	if !config.Key.IsNull() {
 		key = config.Key.ValueString()
 	}
+	endpoint := os.Getenv(endpointEnvName)
+	if !config.Endpoint.IsNull() {
+		endpoint = config.Endpoint.ValueString()
+	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
@@ -97,11 +208,73 @@ func (p *typesenseProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "foo", "bar")
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "foo")
+	clientConf := clientConfig{
+		Key:              key,
+		Endpoint:         endpoint,
+		ProviderVersion:  p.version,
+		TerraformVersion: req.TerraformVersion,
+		MaxRetries:       defaultMaxRetries,
+		RetryWaitMin:     defaultRetryWaitMin,
+		RetryWaitMax:     defaultRetryWaitMax,
+
+		MaxRequestsPerSecond:  defaultMaxRequestsPerSecond,
+		MaxConcurrentRequests: defaultMaxConcurrentRequests,
+
+		Transport: transportConfig{
+			ProxyURL:          config.ProxyURL.ValueString(),
+			CABundle:          config.CABundle.ValueString(),
+			ClientCertificate: config.ClientCertificate.ValueString(),
+			ClientKey:         config.ClientKey.ValueString(),
+		},
+	}
+	if config.ClientCertificate.IsNull() != config.ClientKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_certificate"),
+			"Incomplete Client Certificate Configuration",
+			"Both client_certificate and client_key must be set to use mutual TLS.",
+		)
+	}
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		clientConf.MaxRetries = int(config.MaxRetries.ValueInt64())
+		if clientConf.MaxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				fmt.Sprintf("Expected max_retries to be zero or greater, got %d.", clientConf.MaxRetries),
+			)
+		}
+	}
+	if !config.MaxRequestsPerSecond.IsNull() && !config.MaxRequestsPerSecond.IsUnknown() {
+		clientConf.MaxRequestsPerSecond = config.MaxRequestsPerSecond.ValueFloat64()
+		if clientConf.MaxRequestsPerSecond < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_requests_per_second"),
+				"Invalid Max Requests Per Second",
+				fmt.Sprintf("Expected max_requests_per_second to be zero or greater, got %g.", clientConf.MaxRequestsPerSecond),
+			)
+		}
+	}
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		clientConf.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+		if clientConf.MaxConcurrentRequests < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Max Concurrent Requests",
+				fmt.Sprintf("Expected max_concurrent_requests to be zero or greater, got %d.", clientConf.MaxConcurrentRequests),
+			)
+		}
+	}
+	clientConf.RetryWaitMin = parseDurationAttribute(config.RetryWaitMin, path.Root("retry_wait_min"), clientConf.RetryWaitMin, &resp.Diagnostics)
+	clientConf.RetryWaitMax = parseDurationAttribute(config.RetryWaitMax, path.Root("retry_wait_max"), clientConf.RetryWaitMax, &resp.Diagnostics)
+	clientConf.Transport.RequestTimeout = parseDurationAttribute(config.RequestTimeout, path.Root("request_timeout"), defaultRequestTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.MaskLogStrings(ctx, key)
 
 	// Create a new Typesense client using the configuration values
-	client, err := NewClient(key)
+	client, err := NewClient(clientConf)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Typesense API Client",
@@ -112,10 +285,13 @@ func (p *typesenseProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
+	ctx = tflog.SetField(ctx, "typesense_endpoint", client.endpoint)
+
 	// Make the Typesense client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerData{client: client, poll: poll, maxMonthlyCost: maxMonthlyCost}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 	tflog.Info(ctx, "Configured Typesense client", map[string]any{"success": true})
 }
 
@@ -123,6 +299,8 @@ func (p *typesenseProvider) Configure(ctx context.Context, req provider.Configur
 func (p *typesenseProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewClusterDataSource,
+		NewClustersDataSource,
+		NewClusterOptionsDataSource,
 	}
 }
 
@@ -131,29 +309,370 @@ func (p *typesenseProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewClusterResource,
 		NewClusterApiKeysResource,
+		NewClusterConfigurationChangeResource,
 	}
 }
 
 // typesenseProviderModel maps provider schema data to a Go type.
 type typesenseProviderModel struct {
-	Key types.String `tfsdk:"key"`
+	Key          types.String `tfsdk:"key"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+
+	MaxRequestsPerSecond  types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	ProxyURL          types.String `tfsdk:"proxy_url"`
+	CABundle          types.String `tfsdk:"ca_bundle"`
+	ClientCertificate types.String `tfsdk:"client_certificate"`
+	ClientKey         types.String `tfsdk:"client_key"`
+	RequestTimeout    types.String `tfsdk:"request_timeout"`
+
+	PollInterval    types.String `tfsdk:"poll_interval"`
+	MaxPollInterval types.String `tfsdk:"max_poll_interval"`
+
+	MaxMonthlyCost types.Float64 `tfsdk:"max_monthly_cost"`
 }
 
-// typesenseClusterModel maps Typesense cluster schema data.
+// parseDurationAttribute parses a duration string attribute, returning def when
+// the attribute is not set and adding an attribute error when it is malformed.
+func parseDurationAttribute(value types.String, p path.Path, def time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a positive duration such as \"1s\" or \"500ms\", got %q.", value.ValueString()),
+		)
+		return def
+	}
+	return d
+}
+
+// typesenseClusterModel maps Typesense cluster resource schema data.
 type typesenseClusterModel struct {
-	ID                     types.String          `tfsdk:"id"`
-	Name                   types.String          `tfsdk:"name"`
-	Memory                 types.String          `tfsdk:"memory"`
-	VCPU                   types.String          `tfsdk:"vcpu"`
-	HighPerformanceDisk    types.String          `tfsdk:"high_performance_disk"`
-	TypesenseServerVersion types.String          `tfsdk:"typesense_server_version"`
-	HighAvailability       types.String          `tfsdk:"high_availability"`
-	SearchDeliveryNetwork  types.String          `tfsdk:"search_delivery_network"`
-	LoadBalancing          types.String          `tfsdk:"load_balancing"`
-	Region                 types.String          `tfsdk:"region"`
-	AutoUpgradeCapacity    types.Bool            `tfsdk:"auto_upgrade_capacity"`
-	Status                 types.String          `tfsdk:"status"`
-	Hostnames              basetypes.ObjectValue `tfsdk:"hostnames"`
+	ID                            types.String          `tfsdk:"id"`
+	Name                          types.String          `tfsdk:"name"`
+	Memory                        types.String          `tfsdk:"memory"`
+	VCPU                          types.String          `tfsdk:"vcpu"`
+	HighPerformanceDisk           types.String          `tfsdk:"high_performance_disk"`
+	TypesenseServerVersion        types.String          `tfsdk:"typesense_server_version"`
+	CurrentTypesenseServerVersion types.String          `tfsdk:"current_typesense_server_version"`
+	HighAvailability              types.String          `tfsdk:"high_availability"`
+	NodeCount                     types.Int64           `tfsdk:"node_count"`
+	SearchDeliveryNetwork         types.String          `tfsdk:"search_delivery_network"`
+	LoadBalancing                 types.String          `tfsdk:"load_balancing"`
+	Regions                       types.List            `tfsdk:"regions"`
+	AutoUpgradeCapacity           types.Bool            `tfsdk:"auto_upgrade_capacity"`
+	Status                        types.String          `tfsdk:"status"`
+	Hostnames                     basetypes.ObjectValue `tfsdk:"hostnames"`
+	PerformChangeAt               types.String          `tfsdk:"perform_change_at"`
+	PendingConfigurationChange    basetypes.ObjectValue `tfsdk:"pending_configuration_change"`
+	WaitForTermination            types.Bool            `tfsdk:"wait_for_termination"`
+	DeletionProtection            types.Bool            `tfsdk:"deletion_protection"`
+	EstimatedMonthlyCost          types.Float64         `tfsdk:"estimated_monthly_cost"`
+	Timeouts                      types.Object          `tfsdk:"timeouts"`
+}
+
+// typesenseClusterDataSourceModel maps Typesense cluster data source schema data.
+type typesenseClusterDataSourceModel struct {
+	ID                            types.String          `tfsdk:"id"`
+	Name                          types.String          `tfsdk:"name"`
+	Memory                        types.String          `tfsdk:"memory"`
+	VCPU                          types.String          `tfsdk:"vcpu"`
+	HighPerformanceDisk           types.String          `tfsdk:"high_performance_disk"`
+	TypesenseServerVersion        types.String          `tfsdk:"typesense_server_version"`
+	CurrentTypesenseServerVersion types.String          `tfsdk:"current_typesense_server_version"`
+	HighAvailability              types.String          `tfsdk:"high_availability"`
+	NodeCount                     types.Int64           `tfsdk:"node_count"`
+	SearchDeliveryNetwork         types.String          `tfsdk:"search_delivery_network"`
+	LoadBalancing                 types.String          `tfsdk:"load_balancing"`
+	Region                        types.String          `tfsdk:"region"`
+	Regions                       types.List            `tfsdk:"regions"`
+	AutoUpgradeCapacity           types.Bool            `tfsdk:"auto_upgrade_capacity"`
+	Status                        types.String          `tfsdk:"status"`
+	Hostnames                     basetypes.ObjectValue `tfsdk:"hostnames"`
+	PendingConfigurationChange    basetypes.ObjectValue `tfsdk:"pending_configuration_change"`
+	EstimatedMonthlyCost          types.Float64         `tfsdk:"estimated_monthly_cost"`
+}
+
+// typesenseClustersDataSourceModel maps the typesense_clusters data source schema data.
+type typesenseClustersDataSourceModel struct {
+	ID                                 types.String                      `tfsdk:"id"`
+	NameRegex                          types.String                      `tfsdk:"name_regex"`
+	Status                             types.String                      `tfsdk:"status"`
+	Region                             types.String                      `tfsdk:"region"`
+	Memory                             types.String                      `tfsdk:"memory"`
+	TypesenseServerVersion             types.String                      `tfsdk:"typesense_server_version"`
+	IncludePendingConfigurationChanges types.Bool                        `tfsdk:"include_pending_configuration_changes"`
+	Clusters                           []typesenseClusterDataSourceModel `tfsdk:"clusters"`
+}
+
+// typesenseClusterOptionsDataSourceModel maps the typesense_cluster_options data source schema data.
+type typesenseClusterOptionsDataSourceModel struct {
+	ID                      types.String                 `tfsdk:"id"`
+	MemoryOptions           []typesenseMemoryOptionModel `tfsdk:"memory_options"`
+	VCPUOptions             []typesenseVCPUOptionModel   `tfsdk:"vcpu_options"`
+	Regions                 []typesenseRegionModel       `tfsdk:"regions"`
+	TypesenseServerVersions []types.String               `tfsdk:"typesense_server_versions"`
+}
+
+// typesenseMemoryOptionModel maps a memory tier of the cluster options.
+type typesenseMemoryOptionModel struct {
+	Value types.String   `tfsdk:"value"`
+	VCPU  []types.String `tfsdk:"vcpu"`
+}
+
+// typesenseVCPUOptionModel maps a vCPU option of the cluster options.
+type typesenseVCPUOptionModel struct {
+	Value               types.String `tfsdk:"value"`
+	Burst               types.Bool   `tfsdk:"burst"`
+	HighPerformanceDisk types.Bool   `tfsdk:"high_performance_disk"`
+	HighAvailability    types.Bool   `tfsdk:"high_availability"`
+}
+
+// typesenseRegionModel maps a region of the cluster options.
+type typesenseRegionModel struct {
+	Value types.String `tfsdk:"value"`
+	Name  types.String `tfsdk:"name"`
+}
+
+// hostnamesAttrTypes are the attribute types of the hostnames object.
+var hostnamesAttrTypes = map[string]attr.Type{
+	"load_balanced": types.StringType,
+	"nearest_node":  types.StringType,
+	"nodes":         types.ListType{ElemType: types.StringType},
+}
+
+// pendingChangeAttrTypes are the attribute types of the pending_configuration_change object.
+var pendingChangeAttrTypes = map[string]attr.Type{
+	"id":                           types.StringType,
+	"status":                       types.StringType,
+	"perform_change_at":            types.StringType,
+	"new_memory":                   types.StringType,
+	"new_vcpu":                     types.StringType,
+	"new_high_performance_disk":    types.StringType,
+	"new_typesense_server_version": types.StringType,
+}
+
+// refresh copies the cluster attributes reported by the API into the model.
+func (m *typesenseClusterModel) refresh(cluster *typesenseCluster) {
+	m.ID = types.StringValue(cluster.ID)
+	m.Name = types.StringValue(cluster.Name)
+	m.Memory = types.StringValue(cluster.Memory)
+	m.VCPU = types.StringValue(cluster.VCPU)
+	m.HighPerformanceDisk = types.StringValue(cluster.HighPerformanceDisk)
+	m.TypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
+	m.CurrentTypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
+	m.HighAvailability = types.StringValue(cluster.HighAvailability)
+	m.NodeCount = types.Int64Value(cluster.nodeCount())
+	m.SearchDeliveryNetwork = types.StringValue(cluster.SearchDeliveryNetwork)
+	m.LoadBalancing = types.StringValue(cluster.LoadBalancing)
+	m.Regions = regionsValue(cluster.Regions)
+	m.AutoUpgradeCapacity = types.BoolValue(cluster.AutoUpgradeCapacity)
+	m.Status = types.StringValue(cluster.Status)
+	m.Hostnames = hostnamesValue(cluster.Hostnames)
+}
+
+// refresh copies the cluster attributes reported by the API into the model.
+func (m *typesenseClusterDataSourceModel) refresh(cluster *typesenseCluster, pending *typesenseConfigurationChange) {
+	m.ID = types.StringValue(cluster.ID)
+	m.Name = types.StringValue(cluster.Name)
+	m.Memory = types.StringValue(cluster.Memory)
+	m.VCPU = types.StringValue(cluster.VCPU)
+	m.HighPerformanceDisk = types.StringValue(cluster.HighPerformanceDisk)
+	m.TypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
+	m.CurrentTypesenseServerVersion = types.StringValue(cluster.TypesenseServerVersion)
+	m.HighAvailability = types.StringValue(cluster.HighAvailability)
+	m.NodeCount = types.Int64Value(cluster.nodeCount())
+	m.SearchDeliveryNetwork = types.StringValue(cluster.SearchDeliveryNetwork)
+	m.LoadBalancing = types.StringValue(cluster.LoadBalancing)
+	m.Region = types.StringNull()
+	if len(cluster.Regions) > 0 {
+		m.Region = types.StringValue(cluster.Regions[0])
+	}
+	m.Regions = regionsValue(cluster.Regions)
+	m.AutoUpgradeCapacity = types.BoolValue(cluster.AutoUpgradeCapacity)
+	m.Status = types.StringValue(cluster.Status)
+	m.Hostnames = hostnamesValue(cluster.Hostnames)
+	m.PendingConfigurationChange = pendingChangeValue(pending)
+	m.EstimatedMonthlyCost = cluster.estimatedMonthlyCost()
+}
+
+// estimatedMonthlyCost returns the estimated monthly cost of an existing
+// cluster, or null when its configuration isn't in the pricing table.
+func (tc *typesenseCluster) estimatedMonthlyCost() types.Float64 {
+	nodes := tc.nodeCount()
+	if nodes == 0 {
+		nodes = defaultNodeCount(tc.HighAvailability)
+	}
+	return estimatedMonthlyCost(clusterShape{
+		Memory:              tc.Memory,
+		VCPU:                tc.VCPU,
+		HighPerformanceDisk: tc.HighPerformanceDisk == "yes",
+		Nodes:               nodes,
+		Regions:             len(tc.Regions),
+	})
+}
+
+// refresh sets the options from a catalog.
+func (m *typesenseClusterOptionsDataSourceModel) refresh(c *clusterCatalog) {
+	m.MemoryOptions = make([]typesenseMemoryOptionModel, len(c.Memory))
+	for i, tier := range c.Memory {
+		m.MemoryOptions[i] = typesenseMemoryOptionModel{
+			Value: types.StringValue(tier.Value),
+			VCPU:  stringValues(tier.VCPU),
+		}
+	}
+	m.VCPUOptions = make([]typesenseVCPUOptionModel, len(c.VCPU))
+	for i, option := range c.VCPU {
+		m.VCPUOptions[i] = typesenseVCPUOptionModel{
+			Value:               types.StringValue(option.Value),
+			Burst:               types.BoolValue(option.Burst),
+			HighPerformanceDisk: types.BoolValue(option.HighPerformanceDisk),
+			HighAvailability:    types.BoolValue(option.HighAvailability),
+		}
+	}
+	m.Regions = make([]typesenseRegionModel, len(c.Regions))
+	for i, region := range c.Regions {
+		m.Regions[i] = typesenseRegionModel{
+			Value: types.StringValue(region.Value),
+			Name:  types.StringValue(region.Name),
+		}
+	}
+	m.TypesenseServerVersions = stringValues(c.TypesenseServerVersions)
+}
+
+// stringValues converts strings to string values.
+func stringValues(values []string) []types.String {
+	converted := make([]types.String, len(values))
+	for i, value := range values {
+		converted[i] = types.StringValue(value)
+	}
+	return converted
+}
+
+// regionsValue converts the regions reported by the API to a list value.
+func regionsValue(regions []string) types.List {
+	elements := make([]attr.Value, len(regions))
+	for i, region := range regions {
+		elements[i] = types.StringValue(region)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// hostnamesValue converts the hostnames reported by the API to an object value.
+func hostnamesValue(hostnames typesenseClusterHostnames) types.Object {
+	nodes := make([]attr.Value, len(hostnames.Nodes))
+	for i, node := range hostnames.Nodes {
+		nodes[i] = types.StringValue(node)
+	}
+	return types.ObjectValueMust(hostnamesAttrTypes, map[string]attr.Value{
+		"load_balanced": types.StringValue(hostnames.LoadBalanced),
+		"nearest_node":  types.StringValue(hostnames.NearestNode),
+		"nodes":         types.ListValueMust(types.StringType, nodes),
+	})
+}
+
+// pendingChangeValue converts a pending configuration change to an object
+// value, or a null object when change is nil.
+func pendingChangeValue(change *typesenseConfigurationChange) types.Object {
+	if change == nil {
+		return types.ObjectNull(pendingChangeAttrTypes)
+	}
+	performChangeAt := types.StringNull()
+	if change.PerformChangeAt != 0 {
+		performChangeAt = types.StringValue(time.Unix(change.PerformChangeAt, 0).UTC().Format(time.RFC3339))
+	}
+	return types.ObjectValueMust(pendingChangeAttrTypes, map[string]attr.Value{
+		"id":                           types.StringValue(change.ID),
+		"status":                       types.StringValue(change.Status),
+		"perform_change_at":            performChangeAt,
+		"new_memory":                   optionalString(change.NewMemory),
+		"new_vcpu":                     optionalString(change.NewVCPU),
+		"new_high_performance_disk":    optionalString(change.NewHighPerformanceDisk),
+		"new_typesense_server_version": optionalString(change.NewTypesenseServerVersion),
+	})
+}
+
+// optionalString converts an API value omitted when empty to a string value.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// trackPendingChange records change as the pending configuration change of
+// the cluster and reports its target values, so that a scheduled resize or
+// upgrade shows up as pending rather than as drift until it lands.
+func (m *typesenseClusterModel) trackPendingChange(change *typesenseConfigurationChange) {
+	m.PendingConfigurationChange = pendingChangeValue(change)
+	if change == nil {
+		return
+	}
+	if change.NewMemory != "" {
+		m.Memory = types.StringValue(change.NewMemory)
+	}
+	if change.NewVCPU != "" {
+		m.VCPU = types.StringValue(change.NewVCPU)
+	}
+	if change.NewHighPerformanceDisk != "" {
+		m.HighPerformanceDisk = types.StringValue(change.NewHighPerformanceDisk)
+	}
+	if change.NewTypesenseServerVersion != "" {
+		m.TypesenseServerVersion = types.StringValue(change.NewTypesenseServerVersion)
+	}
+}
+
+// estimateCost sets the estimated monthly cost of the configuration, unknown
+// while the values it depends on are. An unknown node count is the default
+// one of the high availability setting.
+func (m *typesenseClusterModel) estimateCost() {
+	for _, value := range []attr.Value{m.Memory, m.VCPU, m.HighPerformanceDisk, m.HighAvailability, m.Regions} {
+		if value.IsUnknown() {
+			m.EstimatedMonthlyCost = types.Float64Unknown()
+			return
+		}
+	}
+	nodes := m.NodeCount.ValueInt64()
+	if m.NodeCount.IsNull() || m.NodeCount.IsUnknown() {
+		nodes = defaultNodeCount(m.HighAvailability.ValueString())
+	}
+	m.EstimatedMonthlyCost = estimatedMonthlyCost(clusterShape{
+		Memory:              m.Memory.ValueString(),
+		VCPU:                m.VCPU.ValueString(),
+		HighPerformanceDisk: m.HighPerformanceDisk.ValueString() == "yes",
+		Nodes:               nodes,
+		Regions:             len(m.Regions.Elements()),
+	})
+}
+
+// estimatedMonthlyCost returns the monthly cost of a cluster from the
+// embedded pricing table, or null when it has no price for it.
+func estimatedMonthlyCost(shape clusterShape) types.Float64 {
+	cost, ok := pricing.monthlyCost(shape)
+	if !ok {
+		return types.Float64Null()
+	}
+	return types.Float64Value(cost)
+}
+
+// keepDesiredVersion restores the desired server version after a refresh as
+// long as the cluster runs that version or a later one, so that upgrades
+// performed outside of Terraform don't show up as drift.
+func (m *typesenseClusterModel) keepDesiredVersion(desired types.String) {
+	if desired.IsNull() || desired.IsUnknown() {
+		return
+	}
+	if compareVersions(m.CurrentTypesenseServerVersion.ValueString(), desired.ValueString()) >= 0 {
+		m.TypesenseServerVersion = desired
+	}
 }
 
 type typesenseClusterApiKeysModel struct {
@@ -162,3 +681,38 @@ type typesenseClusterApiKeysModel struct {
 	AdminKey      types.String `tfsdk:"admin_key"`
 	SearchOnlyKey types.String `tfsdk:"search_only_key"`
 }
+
+// typesenseClusterConfigurationChangeModel maps Typesense cluster configuration change schema data.
+type typesenseClusterConfigurationChangeModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ClusterID                 types.String `tfsdk:"cluster_id"`
+	NewMemory                 types.String `tfsdk:"new_memory"`
+	NewVCPU                   types.String `tfsdk:"new_vcpu"`
+	NewHighPerformanceDisk    types.String `tfsdk:"new_high_performance_disk"`
+	NewTypesenseServerVersion types.String `tfsdk:"new_typesense_server_version"`
+	PerformChangeAt           types.String `tfsdk:"perform_change_at"`
+	Status                    types.String `tfsdk:"status"`
+	Timeouts                  types.Object `tfsdk:"timeouts"`
+}
+
+// refresh copies the configuration change attributes reported by the API
+// into the model. perform_change_at keeps its configured form as long as it
+// denotes the same time.
+func (m *typesenseClusterConfigurationChangeModel) refresh(change *typesenseConfigurationChange) {
+	m.ID = types.StringValue(change.ID)
+	m.ClusterID = types.StringValue(change.ClusterID)
+	m.NewMemory = optionalString(change.NewMemory)
+	m.NewVCPU = optionalString(change.NewVCPU)
+	m.NewHighPerformanceDisk = optionalString(change.NewHighPerformanceDisk)
+	m.NewTypesenseServerVersion = optionalString(change.NewTypesenseServerVersion)
+	m.Status = types.StringValue(change.Status)
+
+	if change.PerformChangeAt == 0 {
+		m.PerformChangeAt = types.StringNull()
+		return
+	}
+	if configured, err := time.Parse(time.RFC3339, m.PerformChangeAt.ValueString()); err == nil && configured.Unix() == change.PerformChangeAt {
+		return
+	}
+	m.PerformChangeAt = types.StringValue(time.Unix(change.PerformChangeAt, 0).UTC().Format(time.RFC3339))
+}
@@ -1,16 +1,27 @@
 package typesense
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"terraform-provider-typesense/internal/fakecloud"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
-	// providerConfig is a shared configuration to combine with the actual
-	// test configuration so the Typesense client is properly configured.
-	// It is also possible to use the TYPESENSE_MANAGEMENT_KEY environment variable instead,
-	// such as updating the Makefile and running the testing through that tool.
-	providerConfig = `provider "typesense" {}`
+	// testKey is the Cloud Management API key accepted by the fake API.
+	testKey = "test-management-key"
+
+	// tfAccTerraformPathEnvName points the testing framework at a Terraform CLI binary.
+	tfAccTerraformPathEnvName = "TF_ACC_TERRAFORM_PATH"
 )
 
 var (
@@ -19,6 +30,55 @@ var (
 	// CLI command executed to create a provider server to which the CLI can
 	// reattach.
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-		"typesense": providerserver.NewProtocol6WithError(New()),
+		"typesense": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// newFakeCloud starts a fake Cloud Management API for the duration of the test
+// and returns it along with a provider configuration pointing at it, to
+// combine with the actual test configuration. settings are added to the
+// provider block, such as `max_monthly_cost = 20`.
+func newFakeCloud(t *testing.T, settings ...string) (*fakecloud.Server, string) {
+	t.Helper()
+	fake := fakecloud.NewServer(testKey)
+	t.Cleanup(fake.Close)
+	return fake, fmt.Sprintf(`
+provider "typesense" {
+  key            = %q
+  endpoint       = %q
+  retry_wait_min = "10ms"
+  retry_wait_max = "50ms"
+  poll_interval     = "10ms"
+  max_poll_interval = "50ms"
+  # The fake API has no rate limit to respect.
+  max_requests_per_second = 0
+  %s
+}
+`, testKey, fake.URL, strings.Join(settings, "\n  "))
+}
+
+// testAccPreCheck skips resource tests when the Terraform CLI they drive is
+// not available locally. The tests themselves only talk to the fake API.
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv(tfAccTerraformPathEnvName) != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Terraform CLI not found in PATH, install it or set " + tfAccTerraformPathEnvName + " to run resource tests")
+	}
+}
+
+// newTestState returns a resource state for schema s holding model.
+func newTestState(t *testing.T, s schema.Schema, model interface{}) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+	return state
+}
@@ -0,0 +1,86 @@
+package typesense
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter throttles the requests a typesenseClient sends. It combines a
+// token bucket bounding the request rate with a semaphore bounding the number
+// of requests in flight. The zero value of either limit disables it.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens added per second
+	burst    float64 // bucket capacity
+	tokens   float64
+	last     time.Time
+	inFlight chan struct{}
+}
+
+// newRateLimiter returns a limiter allowing requestsPerSecond requests per
+// second and at most maxConcurrent requests at once.
+func newRateLimiter(requestsPerSecond float64, maxConcurrent int) *rateLimiter {
+	rl := &rateLimiter{rate: requestsPerSecond}
+	if requestsPerSecond > 0 {
+		// Allow short bursts of up to one second worth of requests.
+		rl.burst = requestsPerSecond
+		if rl.burst < 1 {
+			rl.burst = 1
+		}
+		rl.tokens = rl.burst
+		rl.last = time.Now()
+	}
+	if maxConcurrent > 0 {
+		rl.inFlight = make(chan struct{}, maxConcurrent)
+	}
+	return rl
+}
+
+// acquire blocks until a request may be sent or ctx is done. On success the
+// returned release function must be called once the request has completed.
+func (rl *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if err := rl.take(ctx); err != nil {
+		return nil, err
+	}
+	if rl.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case rl.inFlight <- struct{}{}:
+		return func() { <-rl.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// take removes a token from the bucket, waiting for one to be refilled if needed.
+func (rl *rateLimiter) take(ctx context.Context) error {
+	if rl.rate <= 0 {
+		return nil
+	}
+	for {
+		rl.mu.Lock()
+		now := time.Now()
+		rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+		if rl.tokens > rl.burst {
+			rl.tokens = rl.burst
+		}
+		rl.last = now
+		if rl.tokens >= 1 {
+			rl.tokens--
+			rl.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - rl.tokens) / rl.rate * float64(time.Second))
+		rl.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package typesense
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterThrottlesRate(t *testing.T) {
+	rl := newRateLimiter(20, 0)
+	start := time.Now()
+	// The first 20 requests use the initial burst, the next 10 need half a second of refill.
+	for i := 0; i < 30; i++ {
+		release, err := rl.acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterBoundsConcurrency(t *testing.T) {
+	rl := newRateLimiter(0, 2)
+	var inFlight, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := rl.acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", peak)
+	}
+}
+
+func TestRateLimiterHonoursContext(t *testing.T) {
+	rl := newRateLimiter(0.1, 0)
+	if _, err := rl.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := rl.acquire(ctx); err == nil {
+		t.Error("expected the wait to be cancelled")
+	}
+}
@@ -0,0 +1,65 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Operations a timeouts block can bound.
+const (
+	timeoutCreate = "create"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+// timeoutsBlock returns the schema of a standard timeouts block accepting a
+// duration for each of operations, such as `timeouts { create = "30m" }`.
+// defaults holds the timeout applied when an operation is not configured.
+func timeoutsBlock(defaults map[string]time.Duration) schema.SingleNestedBlock {
+	attributes := make(map[string]schema.Attribute, len(defaults))
+	for operation, def := range defaults {
+		attributes[operation] = schema.StringAttribute{
+			Description: fmt.Sprintf("Maximum time to wait for the %s operation to complete, as a duration string such as `30m` or `1h`. Defaults to `%s`.", operation, def),
+			Optional:    true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+	return schema.SingleNestedBlock{
+		Description: "Timeouts of long-running operations.",
+		Attributes:  attributes,
+	}
+}
+
+// timeoutsAttrTypes returns the attribute types of a timeouts block built
+// from defaults by timeoutsBlock.
+func timeoutsAttrTypes(defaults map[string]time.Duration) map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(defaults))
+	for operation := range defaults {
+		attrTypes[operation] = types.StringType
+	}
+	return attrTypes
+}
+
+// withTimeout returns a context bounded by the operation timeout configured
+// in the timeouts block, or by the default from defaults when it is not set.
+func withTimeout(ctx context.Context, timeouts types.Object, operation string, defaults map[string]time.Duration) (context.Context, context.CancelFunc, time.Duration) {
+	timeout := defaults[operation]
+	if !timeouts.IsNull() && !timeouts.IsUnknown() {
+		if value, ok := timeouts.Attributes()[operation].(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			// The value was validated at plan time.
+			if d, err := time.ParseDuration(value.ValueString()); err == nil {
+				timeout = d
+			}
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
@@ -0,0 +1,95 @@
+package typesense
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// transportConfig holds the network settings applied to every HTTP client the
+// provider builds, whether it talks to the Cloud Management API or to cluster nodes.
+type transportConfig struct {
+	// ProxyURL is the proxy requests are sent through. When empty the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+	// CABundle is a path to, or the content of, PEM encoded certificates
+	// trusted in addition to the system roots.
+	CABundle string
+	// ClientCertificate and ClientKey are paths to, or the content of, a PEM
+	// encoded certificate and key presented for mutual TLS.
+	ClientCertificate string
+	ClientKey         string
+	// RequestTimeout bounds a single HTTP request, including reading the
+	// response body. Zero means no timeout besides the Terraform context.
+	RequestTimeout time.Duration
+}
+
+// newHTTPClient returns an HTTP client with a pooled transport configured from config.
+func newHTTPClient(config transportConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", config.ProxyURL, err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: expected an absolute URL such as http://proxy:3128", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.CABundle != "" {
+		bundle, err := readPEM(config.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, errors.New("CA bundle does not contain any PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if (config.ClientCertificate == "") != (config.ClientKey == "") {
+		return nil, errors.New("client certificate and client key must be set together")
+	}
+	if config.ClientCertificate != "" {
+		certPEM, err := readPEM(config.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.RequestTimeout,
+	}, nil
+}
+
+// readPEM returns value when it holds PEM data, or the content of the file it points to.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
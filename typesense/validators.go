@@ -0,0 +1,240 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var (
+	_ validator.String         = timestampValidator{}
+	_ validator.String         = durationValidator{}
+	_ validator.String         = oneOfValidator{}
+	_ validator.String         = catalogValidator{}
+	_ validator.List           = catalogValidator{}
+	_ resource.ConfigValidator = clusterCompatibilityValidator{}
+)
+
+// timestampValidator checks that a string attribute holds an RFC 3339 timestamp.
+type timestampValidator struct{}
+
+func (v timestampValidator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 timestamp such as 2023-06-04T02:00:00Z"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			"The "+req.Path.String()+" "+v.Description(ctx)+", got "+req.ConfigValue.String()+": "+err.Error(),
+		)
+	}
+}
+
+// durationValidator checks that a string attribute holds a positive duration
+// such as "30s" or "1h".
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as 30s, 20m or 1h"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			"The "+req.Path.String()+" "+v.Description(ctx)+", got "+req.ConfigValue.String()+".",
+		)
+	}
+}
+
+// oneOfValidator checks that a string attribute holds one of values.
+type oneOfValidator struct {
+	values []string
+}
+
+func (v oneOfValidator) Description(_ context.Context) string {
+	return "value must be one of: " + strings.Join(v.values, ", ")
+}
+
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v oneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Value",
+		"The "+req.Path.String()+" "+v.Description(ctx)+", got "+req.ConfigValue.String()+".",
+	)
+}
+
+// regexpValidator checks that a string attribute holds a valid RE2 regular expression.
+type regexpValidator struct{}
+
+func (v regexpValidator) Description(_ context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			"The "+req.Path.String()+" "+v.Description(ctx)+", got "+req.ConfigValue.String()+": "+err.Error(),
+		)
+	}
+}
+
+// catalogValidator checks that a string attribute, or every element of a list
+// attribute, is one of the values of the embedded catalog, and suggests the
+// closest value on typos.
+type catalogValidator struct {
+	// kind names the values in diagnostics, such as "Memory".
+	kind   string
+	values []string
+}
+
+func (v catalogValidator) Description(_ context.Context) string {
+	return "value must be one of: " + strings.Join(v.values, ", ")
+}
+
+func (v catalogValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v catalogValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validate(req.Path, req.ConfigValue.ValueString(), &resp.Diagnostics)
+}
+
+func (v catalogValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		v.validate(req.Path.AtListIndex(i), value.ValueString(), &resp.Diagnostics)
+	}
+}
+
+func (v catalogValidator) validate(p path.Path, value string, diags *diag.Diagnostics) {
+	for _, valid := range v.values {
+		if value == valid {
+			return
+		}
+	}
+	detail := fmt.Sprintf("%q is not a valid %s option.", value, strings.ToLower(v.kind))
+	if suggestion := suggest(value, v.values); suggestion != "" {
+		detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+	} else {
+		detail += " Valid options are: " + strings.Join(v.values, ", ") + "."
+	}
+	diags.AddAttributeError(p, "Invalid "+v.kind, detail)
+}
+
+// clusterCompatibilityValidator checks that the memory, vCPU, high
+// performance disk and high availability of a configuration can be combined
+// according to the embedded catalog. Attributes are named by the fields, an
+// empty name skips the corresponding check. Values missing from the catalog
+// are left to catalogValidator.
+type clusterCompatibilityValidator struct {
+	memory              string
+	vcpu                string
+	highPerformanceDisk string
+	highAvailability    string
+}
+
+func (v clusterCompatibilityValidator) Description(_ context.Context) string {
+	return "memory, vcpu, high performance disk and high availability must be available together"
+}
+
+func (v clusterCompatibilityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clusterCompatibilityValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	vcpu := v.configString(ctx, req, resp, v.vcpu)
+	option, ok := catalog.vcpuOption(vcpu.ValueString())
+	if vcpu.IsNull() || vcpu.IsUnknown() || !ok {
+		return
+	}
+
+	memory := v.configString(ctx, req, resp, v.memory)
+	if tier, ok := catalog.memoryTier(memory.ValueString()); ok && !tier.allowsVCPU(option.Value) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(v.vcpu),
+			"Incompatible vCPU",
+			fmt.Sprintf("%s %q is not available with %s %q. Options available with %q are: %s.", v.vcpu, option.Value, v.memory, tier.Value, tier.Value, strings.Join(tier.VCPU, ", ")),
+		)
+	}
+	if hpd := v.configString(ctx, req, resp, v.highPerformanceDisk); hpd.ValueString() == "yes" && !option.HighPerformanceDisk {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(v.highPerformanceDisk),
+			"High Performance Disk Not Available",
+			fmt.Sprintf("High performance disk is not available with %s %q, choose a vCPU option without burst or set %s to no.", v.vcpu, option.Value, v.highPerformanceDisk),
+		)
+	}
+	if ha := v.configString(ctx, req, resp, v.highAvailability); ha.ValueString() == "yes" && !option.HighAvailability {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(v.highAvailability),
+			"High Availability Not Available",
+			fmt.Sprintf("High availability is not available with %s %q, choose a larger vCPU option or set %s to no.", v.vcpu, option.Value, v.highAvailability),
+		)
+	}
+}
+
+// configString reads a string attribute of the configuration, returning a
+// null value when name is empty.
+func (v clusterCompatibilityValidator) configString(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse, name string) types.String {
+	value := types.StringNull()
+	if name != "" {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+	}
+	return value
+}
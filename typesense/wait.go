@@ -0,0 +1,180 @@
+package typesense
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultPollInterval    = 8 * time.Second
+	defaultMaxPollInterval = 1 * time.Minute
+	// pollBackoffFactor is how much the delay between two polls grows.
+	pollBackoffFactor = 1.5
+)
+
+// pollConfig controls how long-running operations such as provisioning or
+// resizing a cluster are polled.
+type pollConfig struct {
+	// Interval is the delay before the first poll.
+	Interval time.Duration
+	// MaxInterval caps the delay between two polls as it backs off.
+	MaxInterval time.Duration
+}
+
+// next returns the delay to wait after a poll that was preceded by delay d.
+func (pc pollConfig) next(d time.Duration) time.Duration {
+	d = time.Duration(float64(d) * pollBackoffFactor)
+	if pc.MaxInterval > 0 && d > pc.MaxInterval {
+		return pc.MaxInterval
+	}
+	return d
+}
+
+// statusError reports an operation that ended in a status it cannot recover from.
+type statusError struct {
+	Status string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("reached terminal status %q", e.Status)
+}
+
+// waitForStatus calls refresh with a growing delay until it reports one of
+// the target statuses. It fails as soon as refresh reports one of the failed
+// statuses, returns an error or ctx is done. The last observed status is
+// returned in every case, and included in the error.
+func waitForStatus(ctx context.Context, poll pollConfig, target, failed []string, refresh func(context.Context) (string, error)) (string, error) {
+	var last string
+	delay := poll.Interval
+	for {
+		status, err := refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, timeoutError(ctx, target, last)
+			}
+			return last, err
+		}
+		last = status
+		for _, t := range target {
+			if status == t {
+				return status, nil
+			}
+		}
+		for _, f := range failed {
+			if status == f {
+				return status, &statusError{Status: status}
+			}
+		}
+		tflog.Debug(ctx, "Waiting for status", map[string]any{
+			"status": status,
+			"target": strings.Join(target, ","),
+			"delay":  delay.String(),
+		})
+		if err := sleep(ctx, delay); err != nil {
+			return last, timeoutError(ctx, target, last)
+		}
+		delay = poll.next(delay)
+	}
+}
+
+// timeoutError describes why waiting for target was interrupted.
+func timeoutError(ctx context.Context, target []string, last string) error {
+	reason := "cancelled"
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		reason = "timed out"
+	}
+	if last == "" {
+		return fmt.Errorf("%s waiting for status %s before any status was observed: %w", reason, strings.Join(target, " or "), ctx.Err())
+	}
+	return fmt.Errorf("%s waiting for status %s, last status was %q: %w", reason, strings.Join(target, " or "), last, ctx.Err())
+}
+
+// waitForCluster waits until a cluster is in service and returns it. The
+// cluster last read is returned along with any error.
+func waitForCluster(ctx context.Context, client CloudClient, poll pollConfig, id string) (*typesenseCluster, error) {
+	var cluster *typesenseCluster
+	_, err := waitForStatus(ctx, poll,
+		[]string{clusterStatusInService},
+		[]string{clusterStatusFailed, clusterStatusTerminating, clusterStatusTerminated},
+		func(ctx context.Context) (string, error) {
+			c, err := client.GetCluster(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			cluster = c
+			return c.Status, nil
+		},
+	)
+	return cluster, err
+}
+
+// waitForTermination waits until a cluster is terminated. A cluster that is
+// no longer found counts as terminated.
+func waitForTermination(ctx context.Context, client CloudClient, poll pollConfig, id string) error {
+	_, err := waitForStatus(ctx, poll,
+		[]string{clusterStatusTerminated},
+		nil,
+		func(ctx context.Context) (string, error) {
+			cluster, err := client.GetCluster(ctx, id)
+			if IsNotFound(err) {
+				return clusterStatusTerminated, nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return cluster.Status, nil
+		},
+	)
+	return err
+}
+
+// waitForConfigurationChange polls a configuration change until it completes
+// and the cluster is back in service.
+func waitForConfigurationChange(ctx context.Context, client CloudClient, poll pollConfig, change *typesenseConfigurationChange) (*typesenseConfigurationChange, error) {
+	current, observed := change, change.Status != ""
+	_, err := waitForStatus(ctx, poll,
+		[]string{configurationChangeStatusCompleted},
+		[]string{configurationChangeStatusCancelled, configurationChangeStatusFailed},
+		func(ctx context.Context) (string, error) {
+			// The change as returned on creation counts as the first poll.
+			if observed {
+				observed = false
+				return current.Status, nil
+			}
+			next, err := client.GetConfigurationChange(ctx, change.ClusterID, change.ID)
+			if err != nil {
+				return "", err
+			}
+			current = next
+			return next.Status, nil
+		},
+	)
+	if err != nil {
+		return current, err
+	}
+	if _, err := waitForCluster(ctx, client, poll, change.ClusterID); err != nil {
+		return current, fmt.Errorf("configuration change completed but the cluster did not return to service: %w", err)
+	}
+	return current, nil
+}
+
+// pendingConfigurationChange returns the configuration change scheduled or
+// in progress on a cluster, or nil when there is none.
+func pendingConfigurationChange(ctx context.Context, client CloudClient, clusterID string) (*typesenseConfigurationChange, error) {
+	changes, err := client.ListConfigurationChanges(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	var pending *typesenseConfigurationChange
+	for i := range changes {
+		if changes[i].isPending() {
+			pending = &changes[i]
+		}
+	}
+	return pending, nil
+}
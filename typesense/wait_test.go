@@ -0,0 +1,106 @@
+package typesense
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"terraform-provider-typesense/internal/fakecloud"
+	"testing"
+	"time"
+)
+
+func TestPollConfigNext(t *testing.T) {
+	poll := pollConfig{Interval: time.Second, MaxInterval: 2 * time.Second}
+	delay := poll.Interval
+	for _, want := range []time.Duration{1500 * time.Millisecond, 2 * time.Second, 2 * time.Second} {
+		delay = poll.next(delay)
+		if delay != want {
+			t.Errorf("expected delay %s, got %s", want, delay)
+		}
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	poll := pollConfig{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+	target, failed := []string{"done"}, []string{"failed"}
+	script := func(statuses ...string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			return status, nil
+		}
+	}
+
+	status, err := waitForStatus(context.Background(), poll, target, failed, script("pending", "pending", "done"))
+	if err != nil || status != "done" {
+		t.Errorf("expected to reach done, got %q: %v", status, err)
+	}
+
+	status, err = waitForStatus(context.Background(), poll, target, failed, script("pending", "failed", "done"))
+	var se *statusError
+	if !errors.As(err, &se) || se.Status != "failed" || status != "failed" {
+		t.Errorf("expected a terminal status error, got %q: %v", status, err)
+	}
+
+	refreshErr := errors.New("boom")
+	_, err = waitForStatus(context.Background(), poll, target, failed, func(context.Context) (string, error) {
+		return "", refreshErr
+	})
+	if !errors.Is(err, refreshErr) {
+		t.Errorf("expected the refresh error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	status, err = waitForStatus(ctx, poll, target, failed, script("pending"))
+	if !errors.Is(err, context.DeadlineExceeded) || status != "pending" {
+		t.Fatalf("expected a deadline error, got %q: %v", status, err)
+	}
+	if !strings.Contains(err.Error(), `timed out`) || !strings.Contains(err.Error(), `last status was "pending"`) {
+		t.Errorf("expected the error to mention the timeout and last status, got %v", err)
+	}
+}
+
+func TestWaitForConfigurationChange(t *testing.T) {
+	fake, client := newFakeCloudClient(t)
+	fake.AddCluster(fakecloud.Cluster{ID: "cluster", Memory: "0.5_gb", Regions: []string{"oregon"}})
+	poll := pollConfig{Interval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	created, err := client.CreateConfigurationChange(context.Background(), typesenseConfigurationChange{ClusterID: "cluster", NewMemory: "1_gb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	change, err := waitForConfigurationChange(context.Background(), client, poll, created)
+	if err != nil || change.Status != configurationChangeStatusCompleted {
+		t.Errorf("expected the change to complete, got %+v: %v", change, err)
+	}
+
+	failed := &typesenseConfigurationChange{ID: "failed", ClusterID: "cluster", Status: configurationChangeStatusFailed}
+	if _, err := waitForConfigurationChange(context.Background(), client, poll, failed); err == nil || !strings.Contains(err.Error(), `"failed"`) {
+		t.Errorf("expected a failed change to be reported, got %v", err)
+	}
+}
+
+func TestWaitForTermination(t *testing.T) {
+	fake, client := newFakeCloudClient(t)
+	fake.AddCluster(fakecloud.Cluster{ID: "terminated", Status: fakecloud.StatusTerminated})
+	fake.AddCluster(fakecloud.Cluster{ID: "terminating", Status: fakecloud.StatusTerminating})
+	// The terminating cluster must not finish terminating during the test.
+	fake.TransitionPolls = math.MaxInt32
+	poll := pollConfig{Interval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	for _, id := range []string{"terminated", "missing"} {
+		if err := waitForTermination(context.Background(), client, poll, id); err != nil {
+			t.Errorf("%s: unexpected error: %v", id, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := waitForTermination(ctx, client, poll, "terminating"); err == nil || !strings.Contains(err.Error(), `last status was "terminating"`) {
+		t.Errorf("expected a timeout mentioning the last status, got %v", err)
+	}
+}